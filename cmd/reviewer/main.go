@@ -7,15 +7,33 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"strings"
 
+	"github.com/example/code-reviewer/pkg/cache"
 	ghclient "github.com/example/code-reviewer/pkg/github"
+	"github.com/example/code-reviewer/pkg/lint"
 	"github.com/example/code-reviewer/pkg/reviewer"
 )
 
+// judgeModesByFlag maps a -judge-mode flag value to its reviewer.JudgeMode.
+var judgeModesByFlag = map[string]reviewer.JudgeMode{
+	"standalone": reviewer.ModeStandalone,
+	"pairwise":   reviewer.ModePairwise,
+	"reference":  reviewer.ModeReference,
+}
+
+// availableLinters maps a -linters flag value to its implementation.
+var availableLinters = map[string]lint.Linter{
+	"golangci-lint": lint.GolangCILint{},
+	"ruff":          lint.Ruff{},
+	"eslint":        lint.ESLint{},
+}
+
 func main() {
 	// Parse command line flags
 	owner := flag.String("owner", "", "Repository owner (required)")
@@ -26,6 +44,18 @@ func main() {
 	useJudge := flag.Bool("judge", false, "Use AI judge to filter low-quality suggestions")
 	judgeModel := flag.String("judge-model", "gemini-2.5-flash", "Model to use for judging")
 	judgeMinScore := flag.Float64("judge-min-score", 0.5, "Minimum judge score (0.0-1.0) to include a suggestion")
+	judgeMode := flag.String("judge-mode", "standalone", "Judge mode: standalone, pairwise, or reference")
+	judgeRubricFile := flag.String("judge-rubric-file", "", "Path to a JSON file containing a []reviewer.JudgeCriterion rubric. Empty uses the judge's default rubric.")
+	judgeReferenceFile := flag.String("judge-reference-file", "", "Path to a JSON file containing a reviewer.ReviewResult to compare against in -judge-mode=reference.")
+	checksFlag := flag.String("checks", "", "Comma-separated list of deterministic checks to run (e.g. secret-scan,license-header). Empty runs all of them.")
+	lintersFlag := flag.String("linters", "", "Comma-separated list of pre-pass linters to run (e.g. golangci-lint,ruff,eslint). Empty runs none.")
+	maxInlineComments := flag.Int("max-inline-comments", reviewer.DefaultMaxInlineComments, "Maximum number of inline review comments to post; remaining suggestions go in the summary")
+	cacheDir := flag.String("cache-dir", "", "Directory for a disk cache of GitHub diffs/files/content (keyed by commit SHA) and, when -judge is set, judge verdicts (keyed by suggestion/criterion/model). Empty disables caching.")
+	rateLimitWindow := flag.Duration("rate-limit-window", 0, "Rolling window for -rate-limit-max-events (e.g. 1m). Zero disables rate limiting.")
+	rateLimitMaxEvents := flag.Int("rate-limit-max-events", 1, "Maximum number of reviews allowed per PR within -rate-limit-window.")
+	appID := flag.Int64("app-id", 0, "GitHub App ID to authenticate as, instead of GITHUB_TOKEN. Requires -app-private-key-path.")
+	appInstallationID := flag.Int64("app-installation-id", 0, "Installation ID to authenticate as. If zero, it's resolved automatically for -owner/-repo.")
+	appPrivateKeyPath := flag.String("app-private-key-path", "", "Path to the GitHub App's private key PEM file. Required with -app-id.")
 	flag.Parse()
 
 	// Validate required flags
@@ -40,6 +70,12 @@ func main() {
 		log.Fatalf("Invalid provider %q: must be 'claude' or 'gemini'", *provider)
 	}
 
+	// Validate judge mode
+	mode, ok := judgeModesByFlag[*judgeMode]
+	if !ok {
+		log.Fatalf("Invalid -judge-mode %q: must be 'standalone', 'pairwise', or 'reference'", *judgeMode)
+	}
+
 	// Get Vertex AI configuration (required for both providers)
 	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
 	if projectID == "" {
@@ -50,10 +86,14 @@ func main() {
 		location = "us-east5"
 	}
 
-	// Check for GitHub token
+	// Check for GitHub credentials: either a GitHub App (-app-id) or a
+	// static GITHUB_TOKEN.
 	githubToken := os.Getenv("GITHUB_TOKEN")
-	if githubToken == "" {
-		log.Fatal("GITHUB_TOKEN environment variable is required")
+	if *appID == 0 && githubToken == "" {
+		log.Fatal("GITHUB_TOKEN environment variable is required (or pass -app-id to authenticate as a GitHub App)")
+	}
+	if *appID != 0 && *appPrivateKeyPath == "" {
+		log.Fatal("-app-private-key-path is required with -app-id")
 	}
 
 	ctx := context.Background()
@@ -74,9 +114,37 @@ func main() {
 	}
 
 	// Create GitHub client and set it
-	githubClient := ghclient.NewClient(ctx, githubToken)
+	githubClient, err := newGitHubClient(ctx, githubToken, *appID, *appInstallationID, *appPrivateKeyPath, *owner, *repo)
+	if err != nil {
+		log.Fatalf("Failed to create GitHub client: %v", err)
+	}
+	if *cacheDir != "" {
+		githubClient.SetCache(cache.NewDisk(*cacheDir))
+	}
 	rev.SetGitHub(githubClient)
 
+	if *rateLimitWindow > 0 {
+		rev.WithRateLimiter(*rateLimitWindow, *rateLimitMaxEvents)
+	}
+
+	if *checksFlag != "" {
+		rev.EnabledChecks = strings.Split(*checksFlag, ",")
+	}
+
+	rev.MaxInlineComments = *maxInlineComments
+
+	if *lintersFlag != "" {
+		var linters []lint.Linter
+		for _, name := range strings.Split(*lintersFlag, ",") {
+			l, ok := availableLinters[name]
+			if !ok {
+				log.Fatalf("Unknown linter %q", name)
+			}
+			linters = append(linters, l)
+		}
+		rev.WithLinters(linters...)
+	}
+
 	fmt.Printf("Reviewing PR %s/%s#%d using %s (via Vertex AI)...\n", *owner, *repo, *pr, *provider)
 
 	// Perform the review
@@ -87,6 +155,13 @@ func main() {
 
 	result := output.Result
 
+	if *dryRun && len(output.LintFindings) > 0 {
+		fmt.Println("\n=== Linter Findings (pre-pass, before AI dedup) ===")
+		for _, f := range output.LintFindings {
+			fmt.Printf("  %s:%d [%s/%s] %s\n", f.File, f.Line, f.Rule, f.Severity, f.Message)
+		}
+	}
+
 	// Print the review summary
 	fmt.Println("\n=== Review Summary ===")
 	fmt.Println(result.Summary)
@@ -98,6 +173,24 @@ func main() {
 		Enabled:  *useJudge,
 		Model:    *judgeModel,
 		MinScore: *judgeMinScore,
+		Mode:     mode,
+	}
+	if *cacheDir != "" {
+		judgeConfig.Cache = cache.NewDisk(*cacheDir)
+	}
+	if *judgeRubricFile != "" {
+		var rubric []reviewer.JudgeCriterion
+		if err := readJSONFile(*judgeRubricFile, &rubric); err != nil {
+			log.Fatalf("Failed to read -judge-rubric-file: %v", err)
+		}
+		judgeConfig.Rubric = rubric
+	}
+	if *judgeReferenceFile != "" {
+		var reference reviewer.ReviewResult
+		if err := readJSONFile(*judgeReferenceFile, &reference); err != nil {
+			log.Fatalf("Failed to read -judge-reference-file: %v", err)
+		}
+		judgeConfig.Reference = &reference
 	}
 
 	judgedSuggestions, err := reviewer.JudgeSuggestions(ctx, projectID, location, judgeConfig, result.Suggestions)
@@ -146,3 +239,35 @@ func main() {
 		fmt.Println("\nDry-run mode: Review not posted to GitHub")
 	}
 }
+
+// newGitHubClient creates a static-token client, unless appID is set, in
+// which case it authenticates as that GitHub App installation instead
+// (resolving the installation automatically for owner/repo when
+// installationID is zero).
+func newGitHubClient(ctx context.Context, token string, appID, installationID int64, privateKeyPath, owner, repo string) (*ghclient.Client, error) {
+	if appID == 0 {
+		return ghclient.NewClient(ctx, token), nil
+	}
+
+	privateKeyPEM, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("read app private key: %w", err)
+	}
+
+	if installationID != 0 {
+		return ghclient.NewClientForApp(ctx, appID, installationID, privateKeyPEM)
+	}
+	return ghclient.NewClientForAppAuto(ctx, appID, privateKeyPEM, owner, repo)
+}
+
+// readJSONFile reads path and unmarshals its contents into v.
+func readJSONFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parse %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,22 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package cache defines a byte-addressable artifact store used to avoid
+// re-fetching PR diffs/files/contents and re-evaluating judge verdicts on
+// every rerun of the same commit.
+package cache
+
+import "context"
+
+// Cache stores opaque byte values keyed by an opaque string. Implementations
+// must be safe for concurrent use.
+type Cache interface {
+	// Get returns the cached value for key, or ok=false if there is no
+	// entry (a cache miss is not an error).
+	Get(ctx context.Context, key string) (value []byte, ok bool, err error)
+
+	// Put stores value under key, overwriting any existing entry.
+	Put(ctx context.Context, key string, value []byte) error
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// DiskCache stores artifacts as files under a base directory on the local
+// filesystem. It's the default cache implementation, suitable for a single
+// reviewer instance running on one machine.
+type DiskCache struct {
+	baseDir string
+}
+
+// NewDisk creates a DiskCache rooted at baseDir. The directory is created
+// lazily on first write.
+func NewDisk(baseDir string) *DiskCache {
+	return &DiskCache{baseDir: baseDir}
+}
+
+func (d *DiskCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	data, err := os.ReadFile(d.pathFor(key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return data, true, nil
+}
+
+func (d *DiskCache) Put(_ context.Context, key string, value []byte) error {
+	if err := os.MkdirAll(d.baseDir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(d.pathFor(key), value, 0o644)
+}
+
+// pathFor hashes key so arbitrary cache keys (which may contain slashes,
+// e.g. "owner/repo/sha/path") map to a single flat file per entry.
+func (d *DiskCache) pathFor(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(d.baseDir, hex.EncodeToString(sum[:]))
+}
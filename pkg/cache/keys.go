@@ -0,0 +1,25 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// GitHubArtifactKey builds the cache key for a GitHub artifact (a diff, a
+// file listing, or a file's content) scoped to a specific commit.
+func GitHubArtifactKey(owner, repo, sha, path string) string {
+	return fmt.Sprintf("%s/%s/%s/%s", owner, repo, sha, path)
+}
+
+// JudgeVerdictKey builds the cache key for a judge verdict, so a rerun with
+// the same suggestion, criterion, and model skips the judge call entirely.
+func JudgeVerdictKey(suggestionJSON, criterion, model string) string {
+	sum := sha256.Sum256([]byte(suggestionJSON + criterion + model))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,47 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import "testing"
+
+func TestGitHubArtifactKey(t *testing.T) {
+	tests := []struct {
+		name                   string
+		owner, repo, sha, path string
+		want                   string
+	}{
+		{
+			name:  "diff key",
+			owner: "acme", repo: "widgets", sha: "abc123", path: "diff",
+			want: "acme/widgets/abc123/diff",
+		},
+		{
+			name:  "file content key",
+			owner: "acme", repo: "widgets", sha: "abc123", path: "pkg/foo.go",
+			want: "acme/widgets/abc123/pkg/foo.go",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GitHubArtifactKey(tt.owner, tt.repo, tt.sha, tt.path); got != tt.want {
+				t.Errorf("GitHubArtifactKey(%q, %q, %q, %q) = %q, want %q",
+					tt.owner, tt.repo, tt.sha, tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGitHubArtifactKeyDistinguishesSHAs(t *testing.T) {
+	// A new commit on the same PR must produce a different key, or a
+	// rerun after a "synchronize" webhook would serve the stale artifact
+	// from the previous commit.
+	before := GitHubArtifactKey("acme", "widgets", "sha-before", "diff")
+	after := GitHubArtifactKey("acme", "widgets", "sha-after", "diff")
+	if before == after {
+		t.Errorf("expected different keys for different SHAs, got %q for both", before)
+	}
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cache
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Config configures an S3-compatible object store, the same way
+// woj-server exposes MinIO: an endpoint, bucket, static credentials, and an
+// SSL toggle.
+type S3Config struct {
+	Endpoint  string
+	Bucket    string
+	AccessKey string
+	SecretKey string
+	UseSSL    bool
+}
+
+// S3Cache stores artifacts as objects in an S3-compatible bucket, letting
+// multiple reviewer instances share cached diffs, file contents, and judge
+// verdicts.
+type S3Cache struct {
+	client *minio.Client
+	bucket string
+}
+
+// NewS3 creates an S3Cache from cfg. It does not create the bucket; the
+// bucket is expected to already exist.
+func NewS3(cfg S3Config) (*S3Cache, error) {
+	client, err := minio.New(cfg.Endpoint, &minio.Options{
+		Creds:  credentials.NewStaticV4(cfg.AccessKey, cfg.SecretKey, ""),
+		Secure: cfg.UseSSL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create S3 client: %w", err)
+	}
+	return &S3Cache{client: client, bucket: cfg.Bucket}, nil
+}
+
+func (s *S3Cache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	obj, err := s.client.GetObject(ctx, s.bucket, key, minio.GetObjectOptions{})
+	if err != nil {
+		return nil, false, err
+	}
+	defer obj.Close()
+
+	data, err := io.ReadAll(obj)
+	if err != nil {
+		var resp minio.ErrorResponse
+		if errors.As(err, &resp) && resp.Code == "NoSuchKey" {
+			return nil, false, nil
+		}
+		return nil, false, fmt.Errorf("read object %s: %w", key, err)
+	}
+	return data, true, nil
+}
+
+func (s *S3Cache) Put(ctx context.Context, key string, value []byte) error {
+	_, err := s.client.PutObject(ctx, s.bucket, key, bytes.NewReader(value), int64(len(value)), minio.PutObjectOptions{})
+	if err != nil {
+		return fmt.Errorf("put object %s: %w", key, err)
+	}
+	return nil
+}
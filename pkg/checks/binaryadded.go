@@ -0,0 +1,80 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package checks
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+)
+
+func init() {
+	RegisterCheck("binary-added", BinaryAdded)
+}
+
+// binaryMagicBytes maps a human-readable file kind to the leading bytes
+// that identify it. It's a short, common list, not an exhaustive
+// file-format sniffer.
+var binaryMagicBytes = map[string][]byte{
+	"PNG image":          {0x89, 'P', 'N', 'G'},
+	"JPEG image":         {0xFF, 0xD8, 0xFF},
+	"GIF image":          {'G', 'I', 'F', '8'},
+	"ZIP archive":        {'P', 'K', 0x03, 0x04},
+	"ELF binary":         {0x7F, 'E', 'L', 'F'},
+	"Mach-O binary":      {0xCF, 0xFA, 0xED, 0xFE},
+	"Windows executable": {'M', 'Z'},
+	"PDF document":       {'%', 'P', 'D', 'F'},
+}
+
+// BinaryAdded flags newly added files whose content starts with the magic
+// bytes of a known binary format, so reviewers can ask whether it belongs
+// in Git LFS or an artifact registry instead of the source tree.
+func BinaryAdded(ctx context.Context, req *CheckRequest) CheckResult {
+	result := CheckResult{Score: 10, Severity: SeverityInfo}
+
+	for _, f := range req.Files {
+		if f.GetStatus() != "added" {
+			continue
+		}
+
+		content, err := req.GitHub.GetFileContent(ctx, req.Owner, req.Repo, f.GetFilename(), req.PR.GetHead().GetSHA())
+		if err != nil {
+			// Some binary files can't be fetched through the contents API
+			// at all; that's not itself evidence of a binary blob, so
+			// just skip it rather than failing the whole check.
+			continue
+		}
+
+		if kind, ok := detectBinaryKind(content); ok {
+			result.Findings = append(result.Findings, Finding{
+				File:      f.GetFilename(),
+				LineStart: 1,
+				LineEnd:   1,
+				Severity:  SeverityWarning,
+				Message:   fmt.Sprintf("New binary file added (%s); consider Git LFS or an artifact registry instead of committing binaries to the repo.", kind),
+			})
+		}
+	}
+
+	if len(result.Findings) > 0 {
+		result.Score = 5
+		result.Severity = SeverityWarning
+	}
+
+	return result
+}
+
+// detectBinaryKind reports the first known binary format whose magic
+// bytes prefix content, if any.
+func detectBinaryKind(content string) (string, bool) {
+	data := []byte(content)
+	for kind, magic := range binaryMagicBytes {
+		if len(data) >= len(magic) && bytes.Equal(data[:len(magic)], magic) {
+			return kind, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package checks implements a pluggable, Scorecard-style registry of
+// deterministic PR checks that run alongside (or instead of) the LLM
+// review pass.
+package checks
+
+import (
+	"context"
+
+	ghclient "github.com/example/code-reviewer/pkg/github"
+	gh "github.com/google/go-github/v68/github"
+)
+
+// Severity levels for check results, matching reviewer.CodeSuggestion.
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+)
+
+// ScoreInconclusive is returned by a check that could not reach a verdict,
+// e.g. because it doesn't apply to this PR.
+const ScoreInconclusive = -1
+
+// Finding is a single issue surfaced by a check. It mirrors
+// reviewer.CodeSuggestion's shape so the reviewer package can convert
+// between the two without pkg/checks importing pkg/reviewer.
+type Finding struct {
+	File       string
+	LineStart  int
+	LineEnd    int
+	Severity   string
+	Message    string
+	Suggestion string
+}
+
+// DetailLogger lets a check emit progress and findings without binding to
+// a specific logging implementation.
+type DetailLogger interface {
+	Info(msg string, args ...any)
+	Warn(msg string, args ...any)
+}
+
+// CheckRequest carries everything a CheckFn needs to evaluate a PR.
+type CheckRequest struct {
+	GitHub *ghclient.Client
+	Owner  string
+	Repo   string
+	PR     *gh.PullRequest
+	Files  []*gh.CommitFile
+	Diff   string
+
+	// AddedLines is Diff pre-parsed into its added lines, each tagged with
+	// the file and new-side line number it lands on. Checks that only care
+	// about added content should range over this instead of re-deriving it
+	// from Diff themselves.
+	AddedLines []AddedLine
+
+	Logger DetailLogger
+}
+
+// CheckResult is the outcome of running a single check.
+type CheckResult struct {
+	Name string
+
+	// Score is 0-10 (higher is better), or ScoreInconclusive if the check
+	// doesn't apply to this PR.
+	Score int
+
+	Severity string
+	Findings []Finding
+
+	// Error is set when the check failed to run (as opposed to running
+	// and finding no issues). Runner uses it to decide whether to retry.
+	Error error
+}
+
+// CheckFn evaluates a PR and returns a CheckResult.
+type CheckFn func(context.Context, *CheckRequest) CheckResult
+
+// CheckNameToFnMap holds every registered check, keyed by name. Built-in
+// checks register themselves via init(); out-of-tree checks can do the
+// same from any package that imports pkg/checks.
+var CheckNameToFnMap = map[string]CheckFn{}
+
+// RegisterCheck adds a check to CheckNameToFnMap. It panics on a duplicate
+// name since that always indicates a programming error at init time.
+func RegisterCheck(name string, fn CheckFn) {
+	if _, exists := CheckNameToFnMap[name]; exists {
+		panic("checks: duplicate check registered: " + name)
+	}
+	CheckNameToFnMap[name] = fn
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package checks
+
+import (
+	"context"
+	"regexp"
+)
+
+func init() {
+	RegisterCheck("dangerous-permissions", DangerousPermissions)
+}
+
+// dangerousPermissionPatterns flags diff additions that grant overly broad
+// permissions, mirroring the kinds of findings OSSF Scorecard's
+// Dangerous-Workflow check looks for.
+var dangerousPermissionPatterns = map[string]*regexp.Regexp{
+	"GitHub Actions workflow grants write-all permissions": regexp.MustCompile(`permissions:\s*write-all`),
+	"World-writable file mode":                             regexp.MustCompile(`chmod\s+(-R\s+)?0?777\b`),
+	"Workflow uses the pull_request_target trigger":        regexp.MustCompile(`pull_request_target`),
+}
+
+// DangerousPermissions flags added lines in the diff that grant overly
+// broad permissions to a workflow, script, or file.
+func DangerousPermissions(_ context.Context, req *CheckRequest) CheckResult {
+	result := CheckResult{Score: 10, Severity: SeverityInfo}
+
+	for _, added := range req.AddedLines {
+		for name, pattern := range dangerousPermissionPatterns {
+			if pattern.MatchString(added.Content) {
+				result.Findings = append(result.Findings, Finding{
+					File:      added.File,
+					LineStart: added.Line,
+					LineEnd:   added.Line,
+					Severity:  SeverityWarning,
+					Message:   name + ".",
+				})
+			}
+		}
+	}
+
+	if len(result.Findings) > 0 {
+		result.Score = 3
+		result.Severity = SeverityWarning
+	}
+
+	return result
+}
@@ -0,0 +1,50 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package checks
+
+import (
+	"context"
+	"regexp"
+)
+
+func init() {
+	RegisterCheck("dangerous-url-scheme", DangerousURLScheme)
+}
+
+// dangerousURLSchemePatterns flags added lines that reference a URL
+// scheme commonly abused for XSS, SSRF, or local file disclosure.
+var dangerousURLSchemePatterns = map[string]*regexp.Regexp{
+	"javascript: URL scheme (possible XSS sink)":     regexp.MustCompile(`(?i)\bjavascript:`),
+	"data: URL scheme used for HTML/script content":  regexp.MustCompile(`(?i)\bdata:text/(html|javascript)`),
+	"file:// URL scheme (possible local disclosure)": regexp.MustCompile(`(?i)\bfile://`),
+}
+
+// DangerousURLScheme flags added lines in the diff that reference one of
+// dangerousURLSchemePatterns.
+func DangerousURLScheme(_ context.Context, req *CheckRequest) CheckResult {
+	result := CheckResult{Score: 10, Severity: SeverityInfo}
+
+	for _, added := range req.AddedLines {
+		for name, pattern := range dangerousURLSchemePatterns {
+			if pattern.MatchString(added.Content) {
+				result.Findings = append(result.Findings, Finding{
+					File:      added.File,
+					LineStart: added.Line,
+					LineEnd:   added.Line,
+					Severity:  SeverityWarning,
+					Message:   name + ".",
+				})
+			}
+		}
+	}
+
+	if len(result.Findings) > 0 {
+		result.Score = 4
+		result.Severity = SeverityWarning
+	}
+
+	return result
+}
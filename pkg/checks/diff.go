@@ -0,0 +1,63 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package checks
+
+import "strings"
+
+// AddedLine is a single line added in a unified diff, with the file and
+// line number it lands on in the new version of the file. Unchanged
+// context lines are not included.
+type AddedLine struct {
+	File    string
+	Line    int
+	Content string
+}
+
+// ParseAddedLines walks a unified diff and returns every added line, in
+// order, with the file and new-side line number it belongs to. Checks that
+// only care about added content (SecretScan, DangerousPermissions,
+// DangerousURLScheme) can range over this instead of each hand-rolling the
+// same "+++"/"@@"/"+"/" " walk over req.Diff.
+func ParseAddedLines(diff string) []AddedLine {
+	var added []AddedLine
+
+	file, line := "", 0
+	for _, raw := range strings.Split(diff, "\n") {
+		switch {
+		case strings.HasPrefix(raw, "+++ b/"):
+			file = strings.TrimPrefix(raw, "+++ b/")
+			line = 0
+		case strings.HasPrefix(raw, "@@"):
+			line = parseHunkStartLine(raw)
+		case strings.HasPrefix(raw, "+") && !strings.HasPrefix(raw, "+++"):
+			added = append(added, AddedLine{File: file, Line: line, Content: raw[1:]})
+			line++
+		case strings.HasPrefix(raw, " "):
+			line++
+		}
+	}
+
+	return added
+}
+
+// parseHunkStartLine extracts the starting line number of the "new file"
+// side from a unified diff hunk header, e.g. "@@ -12,3 +15,4 @@".
+func parseHunkStartLine(header string) int {
+	plusIdx := strings.Index(header, "+")
+	if plusIdx == -1 {
+		return 0
+	}
+	rest := header[plusIdx+1:]
+	var start int
+	for i, c := range rest {
+		if c >= '0' && c <= '9' {
+			start = start*10 + int(c-'0')
+		} else if i > 0 {
+			break
+		}
+	}
+	return start
+}
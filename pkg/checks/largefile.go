@@ -0,0 +1,48 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package checks
+
+import (
+	"context"
+	"fmt"
+)
+
+func init() {
+	RegisterCheck("large-file", LargeFile)
+}
+
+// largeFileChangeThreshold is the number of changed lines (additions plus
+// deletions) in a single file beyond which LargeFile flags it. It's a
+// proxy for "hard to review in one pass" rather than a measure of the
+// file's actual byte size, since the PR files API doesn't expose that.
+const largeFileChangeThreshold = 1000
+
+// LargeFile flags files with an unusually large number of changed lines,
+// a common sign of a generated file, a vendored dependency, or a diff
+// that should be split into smaller, reviewable commits.
+func LargeFile(_ context.Context, req *CheckRequest) CheckResult {
+	result := CheckResult{Score: 10, Severity: SeverityInfo}
+
+	for _, f := range req.Files {
+		if f.GetChanges() <= largeFileChangeThreshold {
+			continue
+		}
+		result.Findings = append(result.Findings, Finding{
+			File:      f.GetFilename(),
+			LineStart: 1,
+			LineEnd:   1,
+			Severity:  SeverityWarning,
+			Message:   fmt.Sprintf("File changes %d lines in this PR; consider whether it should be generated, vendored, or split into smaller commits.", f.GetChanges()),
+		})
+	}
+
+	if len(result.Findings) > 0 {
+		result.Score = 6
+		result.Severity = SeverityWarning
+	}
+
+	return result
+}
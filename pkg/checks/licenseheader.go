@@ -0,0 +1,91 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package checks
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+)
+
+func init() {
+	RegisterCheck("license-header", LicenseHeader)
+}
+
+// licenseHeaderExtensions lists the source file extensions this check
+// applies to. Docs, config, and other extensions are exempt; generated
+// files with one of these extensions are checked like any other.
+var licenseHeaderExtensions = map[string]bool{
+	".go": true,
+	".ts": true,
+	".js": true,
+	".py": true,
+}
+
+// licenseMarkers are the substrings we accept as evidence of a license
+// header; a repo using this check can carry any one of these conventions.
+var licenseMarkers = []string{
+	"SPDX-License-Identifier",
+	"Copyright",
+}
+
+// LicenseHeader flags newly added source files that are missing a license
+// header in their first few lines.
+func LicenseHeader(ctx context.Context, req *CheckRequest) CheckResult {
+	result := CheckResult{Score: 10, Severity: SeverityInfo}
+
+	for _, f := range req.Files {
+		if f.GetStatus() != "added" {
+			continue
+		}
+		path := f.GetFilename()
+		if !licenseHeaderExtensions[filepath.Ext(path)] {
+			continue
+		}
+
+		content, err := req.GitHub.GetFileContent(ctx, req.Owner, req.Repo, path, req.PR.GetHead().GetSHA())
+		if err != nil {
+			// A single unreadable file (e.g. a 404 on a submodule path)
+			// isn't evidence of a missing header; skip it rather than
+			// discarding findings already collected for other files.
+			continue
+		}
+
+		if !hasLicenseHeader(content) {
+			result.Findings = append(result.Findings, Finding{
+				File:      path,
+				LineStart: 1,
+				LineEnd:   1,
+				Severity:  SeverityWarning,
+				Message:   "New source file is missing a license header.",
+			})
+		}
+	}
+
+	if len(result.Findings) > 0 {
+		result.Score = 5
+		result.Severity = SeverityWarning
+	}
+
+	return result
+}
+
+// hasLicenseHeader reports whether any of licenseMarkers appears in the
+// first few lines of content.
+func hasLicenseHeader(content string) bool {
+	lines := strings.Split(content, "\n")
+	if len(lines) > 10 {
+		lines = lines[:10]
+	}
+	head := strings.Join(lines, "\n")
+
+	for _, marker := range licenseMarkers {
+		if strings.Contains(head, marker) {
+			return true
+		}
+	}
+	return false
+}
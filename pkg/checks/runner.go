@@ -0,0 +1,131 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package checks
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// DefaultMaxRetries is how many times Runner retries a check whose error
+// looks transient before giving up and returning that error.
+const DefaultMaxRetries = 2
+
+// Runner executes a set of registered checks concurrently.
+type Runner struct {
+	// Names selects which checks to run. If empty, every check in
+	// CheckNameToFnMap is run.
+	Names []string
+
+	// MaxRetries overrides DefaultMaxRetries when non-zero.
+	MaxRetries int
+
+	// RetryBackoff is the base delay between retries; it doubles each
+	// attempt. Defaults to 500ms.
+	RetryBackoff time.Duration
+}
+
+// Run executes the configured checks concurrently against req and returns
+// one CheckResult per check, in the same order as Names. If Names is
+// empty, every registered check runs and the order is not defined.
+func (r *Runner) Run(ctx context.Context, req *CheckRequest) []CheckResult {
+	names := r.Names
+	if len(names) == 0 {
+		for name := range CheckNameToFnMap {
+			names = append(names, name)
+		}
+	}
+
+	results := make([]CheckResult, len(names))
+
+	var wg sync.WaitGroup
+	for i, name := range names {
+		wg.Add(1)
+		go func(i int, name string) {
+			defer wg.Done()
+			fn, ok := CheckNameToFnMap[name]
+			if !ok {
+				results[i] = CheckResult{
+					Name:  name,
+					Score: ScoreInconclusive,
+					Error: fmt.Errorf("unknown check %q", name),
+				}
+				return
+			}
+			results[i] = r.runWithRetry(ctx, name, fn, req)
+		}(i, name)
+	}
+	wg.Wait()
+
+	return results
+}
+
+// runWithRetry runs fn, retrying on errors that ShouldRetry classifies as
+// transient, up to MaxRetries times with exponential backoff.
+func (r *Runner) runWithRetry(ctx context.Context, name string, fn CheckFn, req *CheckRequest) CheckResult {
+	maxRetries := r.MaxRetries
+	if maxRetries == 0 {
+		maxRetries = DefaultMaxRetries
+	}
+	backoff := r.RetryBackoff
+	if backoff == 0 {
+		backoff = 500 * time.Millisecond
+	}
+
+	var result CheckResult
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		result = fn(ctx, req)
+		result.Name = name
+
+		if result.Error == nil || !ShouldRetry(result.Error) {
+			return result
+		}
+
+		if attempt == maxRetries {
+			break
+		}
+
+		if req.Logger != nil {
+			req.Logger.Warn("check failed, retrying",
+				"check", name, "attempt", attempt+1, "error", result.Error)
+		}
+
+		select {
+		case <-time.After(backoff * time.Duration(1<<attempt)):
+		case <-ctx.Done():
+			result.Error = ctx.Err()
+			return result
+		}
+	}
+
+	return result
+}
+
+// temporary is the subset of net.Error (and similar transient-error types)
+// that ShouldRetry looks for.
+type temporary interface {
+	Temporary() bool
+}
+
+// ShouldRetry reports whether err looks like a transient failure (timeout,
+// rate limit, temporary network error) worth retrying, as opposed to a
+// permanent one (bad input, not found) that will never succeed on replay.
+func ShouldRetry(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		return true
+	}
+	var t temporary
+	if errors.As(err, &t) {
+		return t.Temporary()
+	}
+	return false
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package checks
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+func init() {
+	RegisterCheck("secret-scan", SecretScan)
+}
+
+// secretPatterns is a basic set of regexes for common cloud credential
+// formats. It's intentionally conservative (few false positives) rather
+// than exhaustive; it's a deterministic first pass, not a replacement for
+// a dedicated secret scanner.
+var secretPatterns = map[string]*regexp.Regexp{
+	"AWS Access Key ID":     regexp.MustCompile(`\bAKIA[0-9A-Z]{16}\b`),
+	"GCP API Key":           regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`),
+	"GCP Service Account":   regexp.MustCompile(`"type":\s*"service_account"`),
+	"Generic Private Key":   regexp.MustCompile(`-----BEGIN (RSA |EC |OPENSSH )?PRIVATE KEY-----`),
+	"GitHub Personal Token": regexp.MustCompile(`\bgh[pousr]_[0-9A-Za-z]{36,}\b`),
+}
+
+// SecretScan flags added lines in the diff that look like a hardcoded
+// credential.
+func SecretScan(_ context.Context, req *CheckRequest) CheckResult {
+	result := CheckResult{Score: 10, Severity: SeverityInfo}
+
+	for _, added := range req.AddedLines {
+		for name, pattern := range secretPatterns {
+			if pattern.MatchString(added.Content) {
+				result.Findings = append(result.Findings, Finding{
+					File:      added.File,
+					LineStart: added.Line,
+					LineEnd:   added.Line,
+					Severity:  SeverityError,
+					Message:   fmt.Sprintf("Possible hardcoded credential (%s) added here.", name),
+				})
+			}
+		}
+	}
+
+	if len(result.Findings) > 0 {
+		result.Score = 0
+		result.Severity = SeverityError
+	}
+
+	return result
+}
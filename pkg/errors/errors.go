@@ -0,0 +1,95 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package errors provides typed errors that let callers (webhooks, CI
+// runners) distinguish between a problem the PR author needs to fix, an
+// outage in a dependency, and a rate limit that should be backed off and
+// retried.
+package errors
+
+import (
+	stderrors "errors"
+	"fmt"
+	"time"
+)
+
+// UserError indicates the request itself is the problem: bad input,
+// insufficient permissions, a PR that doesn't exist. Retrying without the
+// caller changing something will fail the same way every time.
+type UserError struct {
+	Cause   error
+	Message string
+}
+
+func NewUserError(cause error, format string, args ...any) *UserError {
+	return &UserError{Cause: cause, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *UserError) Error() string { return e.Message }
+func (e *UserError) Unwrap() error { return e.Cause }
+
+// Retryable reports whether retrying the operation could succeed. It's
+// always false for UserError.
+func (e *UserError) Retryable() bool { return false }
+
+// ServiceFault indicates a dependency (GitHub, Vertex AI) failed in a way
+// unrelated to the caller's input, e.g. a 5xx response or a network error.
+// These are usually worth retrying with backoff.
+type ServiceFault struct {
+	Cause   error
+	Message string
+}
+
+func NewServiceFault(cause error, format string, args ...any) *ServiceFault {
+	return &ServiceFault{Cause: cause, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *ServiceFault) Error() string { return e.Message }
+func (e *ServiceFault) Unwrap() error { return e.Cause }
+
+// Retryable is true for ServiceFault: the same request may well succeed
+// once the dependency recovers.
+func (e *ServiceFault) Retryable() bool { return true }
+
+// Temporary mirrors Retryable so ServiceFault satisfies the net.Error-style
+// "Temporary() bool" interface that pkg/checks.ShouldRetry looks for.
+func (e *ServiceFault) Temporary() bool { return true }
+
+// TooManyRequestsError indicates the caller has been rate limited, either
+// by GitHub's primary/secondary rate limits or Vertex AI quota. RetryAfter
+// is the server's suggested backoff, when known.
+type TooManyRequestsError struct {
+	Cause      error
+	Message    string
+	RetryAfter time.Duration
+}
+
+func NewTooManyRequestsError(cause error, retryAfter time.Duration, format string, args ...any) *TooManyRequestsError {
+	return &TooManyRequestsError{Cause: cause, Message: fmt.Sprintf(format, args...), RetryAfter: retryAfter}
+}
+
+func (e *TooManyRequestsError) Error() string { return e.Message }
+func (e *TooManyRequestsError) Unwrap() error { return e.Cause }
+
+// Retryable is true for TooManyRequestsError: the request should succeed
+// after RetryAfter (or a default backoff) has elapsed.
+func (e *TooManyRequestsError) Retryable() bool { return true }
+
+// Temporary mirrors Retryable so TooManyRequestsError satisfies the
+// net.Error-style "Temporary() bool" interface that
+// pkg/checks.ShouldRetry looks for.
+func (e *TooManyRequestsError) Temporary() bool { return true }
+
+// Retryable reports whether err is one of the typed errors in this package
+// and, if so, whether retrying the operation could succeed. Errors not
+// classified by this package are treated as not retryable.
+func Retryable(err error) bool {
+	type retryable interface{ Retryable() bool }
+	var r retryable
+	if stderrors.As(err, &r) {
+		return r.Retryable()
+	}
+	return false
+}
@@ -7,23 +7,54 @@ package github
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
+	"github.com/bradleyfalzon/ghinstallation/v2"
+	"github.com/example/code-reviewer/pkg/cache"
+	rerrors "github.com/example/code-reviewer/pkg/errors"
 	"github.com/google/go-github/v68/github"
 	"golang.org/x/oauth2"
 )
 
 // Client wraps the GitHub API client for PR operations.
 type Client struct {
-	gh *github.Client
+	gh    *github.Client
+	cache cache.Cache
+
+	// installationID is set when the client was created with
+	// NewClientForApp or NewClientForAppAuto, and zero for static-token
+	// clients.
+	installationID int64
+
+	// token is the static token this client was created with, used by
+	// CloneCredential to authenticate a git clone the same way this
+	// client authenticates its REST calls. Empty for an App-based client
+	// or one created with NewClientWithHTTP.
+	token string
+
+	// appTransport mints short-lived installation tokens on demand when
+	// this client was created with NewClientForApp or
+	// NewClientForAppAuto.
+	appTransport *ghinstallation.Transport
+
+	// dedup tracks the last posted review body hash per owner/repo/sha so
+	// an identical rerun on an unchanged commit skips the API call.
+	dedupMu sync.Mutex
+	dedup   map[string]string
 }
 
 // NewClient creates a new GitHub client with the provided token.
 func NewClient(ctx context.Context, token string) *Client {
 	ts := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token})
 	tc := oauth2.NewClient(ctx, ts)
-	return &Client{gh: github.NewClient(tc)}
+	return &Client{gh: github.NewClient(tc), token: token}
 }
 
 // NewClientWithHTTP creates a GitHub client with a custom HTTP client.
@@ -31,35 +62,86 @@ func NewClientWithHTTP(httpClient *http.Client) *Client {
 	return &Client{gh: github.NewClient(httpClient)}
 }
 
+// SetCache configures an artifact cache so that diffs, file listings, and
+// file contents for an unchanged commit are fetched once and reused across
+// reruns (and, with a shared cache like S3Cache, across reviewer instances).
+func (c *Client) SetCache(cache cache.Cache) {
+	c.cache = cache
+}
+
+// CloneCredential returns a token to authenticate a git clone over HTTPS
+// (as the password in https://x-access-token:<token>@github.com/...),
+// matching however this client authenticates its REST calls. For an
+// App-based client it mints a fresh installation token on every call, so
+// it's safe to use even for a long-lived reviewer process. Returns an
+// empty string, with no error, for a client with no credential (e.g.
+// NewClientWithHTTP), in which case the caller should fall back to an
+// unauthenticated clone.
+func (c *Client) CloneCredential(ctx context.Context) (string, error) {
+	if c.appTransport != nil {
+		token, err := c.appTransport.Token(ctx)
+		if err != nil {
+			return "", fmt.Errorf("mint installation token: %w", err)
+		}
+		return token, nil
+	}
+	return c.token, nil
+}
+
 // GetPR fetches the pull request metadata.
 func (c *Client) GetPR(ctx context.Context, owner, repo string, number int) (*github.PullRequest, error) {
 	pr, _, err := c.gh.PullRequests.Get(ctx, owner, repo, number)
 	if err != nil {
-		return nil, fmt.Errorf("get PR: %w", err)
+		return nil, classifyError(err, "get PR")
 	}
 	return pr, nil
 }
 
-// GetPRDiff fetches the raw diff for a pull request.
-func (c *Client) GetPRDiff(ctx context.Context, owner, repo string, number int) (string, error) {
+// GetPRDiff fetches the raw diff for a pull request, caching it under
+// owner/repo/sha/diff so a rerun on the same head commit is free but a
+// new commit pushed to the PR (e.g. a "synchronize" webhook) always gets
+// a fresh fetch instead of the previous commit's stale diff.
+func (c *Client) GetPRDiff(ctx context.Context, owner, repo string, number int, sha string) (string, error) {
+	key := cache.GitHubArtifactKey(owner, repo, sha, "diff")
+	if cached, ok, err := c.cacheGet(ctx, key); err != nil {
+		return "", err
+	} else if ok {
+		return string(cached), nil
+	}
+
 	diff, _, err := c.gh.PullRequests.GetRaw(ctx, owner, repo, number, github.RawOptions{
 		Type: github.Diff,
 	})
 	if err != nil {
-		return "", fmt.Errorf("get PR diff: %w", err)
+		return "", classifyError(err, "get PR diff")
 	}
+
+	c.cachePut(ctx, key, []byte(diff))
 	return diff, nil
 }
 
-// GetPRFiles fetches the list of files changed in a pull request.
-func (c *Client) GetPRFiles(ctx context.Context, owner, repo string, number int) ([]*github.CommitFile, error) {
+// GetPRFiles fetches the list of files changed in a pull request, caching
+// the result under owner/repo/sha/files so a new commit on the PR isn't
+// served the previous commit's file list.
+func (c *Client) GetPRFiles(ctx context.Context, owner, repo string, number int, sha string) ([]*github.CommitFile, error) {
+	key := cache.GitHubArtifactKey(owner, repo, sha, "files")
+	if cached, ok, err := c.cacheGet(ctx, key); err != nil {
+		return nil, err
+	} else if ok {
+		var files []*github.CommitFile
+		if err := json.Unmarshal(cached, &files); err == nil {
+			return files, nil
+		}
+		// Fall through and re-fetch on a corrupt cache entry.
+	}
+
 	opts := &github.ListOptions{PerPage: 100}
 	var allFiles []*github.CommitFile
 
 	for {
 		files, resp, err := c.gh.PullRequests.ListFiles(ctx, owner, repo, number, opts)
 		if err != nil {
-			return nil, fmt.Errorf("list PR files: %w", err)
+			return nil, classifyError(err, "list PR files")
 		}
 		allFiles = append(allFiles, files...)
 
@@ -69,20 +151,33 @@ func (c *Client) GetPRFiles(ctx context.Context, owner, repo string, number int)
 		opts.Page = resp.NextPage
 	}
 
+	if encoded, err := json.Marshal(allFiles); err == nil {
+		c.cachePut(ctx, key, encoded)
+	}
+
 	return allFiles, nil
 }
 
-// GetFileContent fetches the content of a file at a specific commit SHA.
+// GetFileContent fetches the content of a file at a specific commit SHA,
+// caching it under owner/repo/sha/path since content at a given SHA is
+// immutable.
 func (c *Client) GetFileContent(ctx context.Context, owner, repo, path, ref string) (string, error) {
+	key := cache.GitHubArtifactKey(owner, repo, ref, path)
+	if cached, ok, err := c.cacheGet(ctx, key); err != nil {
+		return "", err
+	} else if ok {
+		return string(cached), nil
+	}
+
 	content, _, _, err := c.gh.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{
 		Ref: ref,
 	})
 	if err != nil {
-		return "", fmt.Errorf("get file content: %w", err)
+		return "", classifyError(err, "get file content")
 	}
 
 	if content == nil {
-		return "", fmt.Errorf("file %s not found", path)
+		return "", rerrors.NewUserError(nil, "file %s not found", path)
 	}
 
 	decoded, err := content.GetContent()
@@ -90,18 +185,230 @@ func (c *Client) GetFileContent(ctx context.Context, owner, repo, path, ref stri
 		return "", fmt.Errorf("decode file content: %w", err)
 	}
 
+	c.cachePut(ctx, key, []byte(decoded))
 	return decoded, nil
 }
 
+// ListDir fetches the entries of a directory at a specific commit SHA.
+func (c *Client) ListDir(ctx context.Context, owner, repo, path, ref string) ([]*github.RepositoryContent, error) {
+	_, dirContents, _, err := c.gh.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{
+		Ref: ref,
+	})
+	if err != nil {
+		return nil, classifyError(err, "list directory")
+	}
+	if dirContents == nil {
+		return nil, rerrors.NewUserError(nil, "%s is not a directory", path)
+	}
+	return dirContents, nil
+}
+
+// SearchCode searches for code within owner/repo using GitHub's code
+// search syntax, optionally restricted to paths matching pathGlob.
+// maxResults caps the number of results returned; zero means GitHub's
+// default page size.
+func (c *Client) SearchCode(ctx context.Context, owner, repo, query, pathGlob string, maxResults int) ([]*github.CodeResult, error) {
+	q := fmt.Sprintf("%s repo:%s/%s", query, owner, repo)
+	if pathGlob != "" {
+		q += fmt.Sprintf(" path:%s", pathGlob)
+	}
+
+	opts := &github.SearchOptions{}
+	if maxResults > 0 {
+		opts.ListOptions = github.ListOptions{PerPage: maxResults}
+	}
+
+	result, _, err := c.gh.Search.Code(ctx, q, opts)
+	if err != nil {
+		return nil, classifyError(err, "search code")
+	}
+
+	items := result.CodeResults
+	if maxResults > 0 && len(items) > maxResults {
+		items = items[:maxResults]
+	}
+	return items, nil
+}
+
+// cacheGet is a no-op miss when no cache is configured.
+func (c *Client) cacheGet(ctx context.Context, key string) ([]byte, bool, error) {
+	if c.cache == nil {
+		return nil, false, nil
+	}
+	return c.cache.Get(ctx, key)
+}
+
+// cachePut is a no-op when no cache is configured. Cache write failures are
+// not fatal to the calling operation, which already has the fetched data.
+func (c *Client) cachePut(ctx context.Context, key string, value []byte) {
+	if c.cache == nil {
+		return
+	}
+	_ = c.cache.Put(ctx, key, value)
+}
+
+// ListReviews fetches every review posted on a pull request, newest last.
+func (c *Client) ListReviews(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestReview, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var allReviews []*github.PullRequestReview
+
+	for {
+		reviews, resp, err := c.gh.PullRequests.ListReviews(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, classifyError(err, "list PR reviews")
+		}
+		allReviews = append(allReviews, reviews...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allReviews, nil
+}
+
+// DismissReview dismisses a previously submitted review. GitHub only
+// allows dismissing reviews that requested changes or approved the PR;
+// dismissing a COMMENT-only review returns an error, which callers should
+// treat as non-fatal.
+func (c *Client) DismissReview(ctx context.Context, owner, repo string, number int, reviewID int64, message string) error {
+	_, _, err := c.gh.PullRequests.DismissReview(ctx, owner, repo, number, reviewID, &github.PullRequestReviewDismissalRequest{
+		Message: Ptr(message),
+	})
+	if err != nil {
+		return classifyError(err, "dismiss review")
+	}
+	return nil
+}
+
+// UpdateReview edits the body of a previously submitted review in place.
+// Unlike DismissReview, this works regardless of the review's state, so
+// it's the only way to clean up a stale COMMENT-state review that GitHub
+// won't allow dismissing.
+func (c *Client) UpdateReview(ctx context.Context, owner, repo string, number int, reviewID int64, body string) error {
+	_, _, err := c.gh.PullRequests.UpdateReview(ctx, owner, repo, number, reviewID, body)
+	if err != nil {
+		return classifyError(err, "update review")
+	}
+	return nil
+}
+
+// ListReviewComments fetches every inline comment posted on a pull
+// request's diff, across all reviews. Unlike a review's top-level body,
+// these are a separate entity that DismissReview/UpdateReview don't
+// touch.
+func (c *Client) ListReviewComments(ctx context.Context, owner, repo string, number int) ([]*github.PullRequestComment, error) {
+	opts := &github.PullRequestListCommentsOptions{ListOptions: github.ListOptions{PerPage: 100}}
+	var allComments []*github.PullRequestComment
+
+	for {
+		comments, resp, err := c.gh.PullRequests.ListComments(ctx, owner, repo, number, opts)
+		if err != nil {
+			return nil, classifyError(err, "list PR review comments")
+		}
+		allComments = append(allComments, comments...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allComments, nil
+}
+
+// DeleteReviewComment removes a single inline review comment.
+func (c *Client) DeleteReviewComment(ctx context.Context, owner, repo string, commentID int64) error {
+	_, err := c.gh.PullRequests.DeleteComment(ctx, owner, repo, commentID)
+	if err != nil {
+		return classifyError(err, "delete review comment")
+	}
+	return nil
+}
+
 // CreateReview submits a review to a pull request.
 func (c *Client) CreateReview(ctx context.Context, owner, repo string, number int, review *github.PullRequestReviewRequest) (*github.PullRequestReview, error) {
+	if c.isDuplicateReview(owner, repo, review) {
+		return nil, nil
+	}
+
 	created, _, err := c.gh.PullRequests.CreateReview(ctx, owner, repo, number, review)
 	if err != nil {
-		return nil, fmt.Errorf("create review: %w", err)
+		return nil, classifyError(err, "create review")
 	}
+
+	c.recordReview(owner, repo, review)
 	return created, nil
 }
 
+// isDuplicateReview reports whether the identical review body was already
+// posted for the same commit SHA, so the caller can skip the API call
+// entirely instead of spamming the PR with repeat comments.
+func (c *Client) isDuplicateReview(owner, repo string, review *github.PullRequestReviewRequest) bool {
+	key := owner + "/" + repo + "/" + review.GetCommitID()
+	hash := hashReviewBody(review.GetBody())
+
+	c.dedupMu.Lock()
+	defer c.dedupMu.Unlock()
+
+	return c.dedup != nil && c.dedup[key] == hash
+}
+
+// recordReview remembers the hash of the review body just posted for this
+// commit, so a subsequent identical rerun is recognized as a duplicate.
+func (c *Client) recordReview(owner, repo string, review *github.PullRequestReviewRequest) {
+	key := owner + "/" + repo + "/" + review.GetCommitID()
+	hash := hashReviewBody(review.GetBody())
+
+	c.dedupMu.Lock()
+	defer c.dedupMu.Unlock()
+
+	if c.dedup == nil {
+		c.dedup = make(map[string]string)
+	}
+	c.dedup[key] = hash
+}
+
+func hashReviewBody(body string) string {
+	sum := sha256.Sum256([]byte(body))
+	return hex.EncodeToString(sum[:])
+}
+
+// classifyError maps a go-github error into the typed errors callers use
+// to decide whether to post an actionable PR comment, retry with backoff,
+// or page an operator.
+func classifyError(err error, op string) error {
+	var rateLimitErr *github.RateLimitError
+	if errors.As(err, &rateLimitErr) {
+		retryAfter := time.Until(rateLimitErr.Rate.Reset.Time)
+		return rerrors.NewTooManyRequestsError(err, retryAfter, "%s: hit GitHub rate limit: %v", op, err)
+	}
+
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		var retryAfter time.Duration
+		if abuseErr.RetryAfter != nil {
+			retryAfter = *abuseErr.RetryAfter
+		}
+		return rerrors.NewTooManyRequestsError(err, retryAfter, "%s: hit GitHub secondary rate limit: %v", op, err)
+	}
+
+	var errResp *github.ErrorResponse
+	if errors.As(err, &errResp) && errResp.Response != nil {
+		switch errResp.Response.StatusCode {
+		case http.StatusForbidden, http.StatusNotFound, http.StatusUnprocessableEntity:
+			return rerrors.NewUserError(err, "%s: %v", op, err)
+		default:
+			if errResp.Response.StatusCode >= 500 {
+				return rerrors.NewServiceFault(err, "%s: %v", op, err)
+			}
+		}
+	}
+
+	return rerrors.NewServiceFault(err, "%s: %v", op, err)
+}
+
 // Ptr is a helper to get a pointer to a value.
 func Ptr[T any](v T) *T {
 	return &v
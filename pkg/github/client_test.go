@@ -0,0 +1,111 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package github
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	rerrors "github.com/example/code-reviewer/pkg/errors"
+	"github.com/google/go-github/v68/github"
+)
+
+// stubResponse builds an *http.Response with a non-nil Request, since the
+// go-github error types' Error() methods format the request method/URL
+// and would otherwise panic on a nil Request.
+func stubResponse(status int) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Request:    &http.Request{Method: "GET", URL: &url.URL{Path: "/test"}},
+	}
+}
+
+func TestClassifyError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want any // the concrete *rerrors.* type expected
+	}{
+		{
+			name: "primary rate limit becomes TooManyRequestsError",
+			err: &github.RateLimitError{
+				Response: stubResponse(http.StatusForbidden),
+				Rate:     github.Rate{Reset: github.Timestamp{Time: time.Now().Add(time.Minute)}},
+			},
+			want: &rerrors.TooManyRequestsError{},
+		},
+		{
+			name: "secondary (abuse) rate limit becomes TooManyRequestsError",
+			err: &github.AbuseRateLimitError{
+				Response: stubResponse(http.StatusForbidden),
+			},
+			want: &rerrors.TooManyRequestsError{},
+		},
+		{
+			name: "403 becomes UserError",
+			err: &github.ErrorResponse{
+				Response: stubResponse(http.StatusForbidden),
+				Message:  "forbidden",
+			},
+			want: &rerrors.UserError{},
+		},
+		{
+			name: "404 becomes UserError",
+			err: &github.ErrorResponse{
+				Response: stubResponse(http.StatusNotFound),
+				Message:  "not found",
+			},
+			want: &rerrors.UserError{},
+		},
+		{
+			name: "422 becomes UserError",
+			err: &github.ErrorResponse{
+				Response: stubResponse(http.StatusUnprocessableEntity),
+				Message:  "unprocessable",
+			},
+			want: &rerrors.UserError{},
+		},
+		{
+			name: "500 becomes ServiceFault",
+			err: &github.ErrorResponse{
+				Response: stubResponse(http.StatusInternalServerError),
+				Message:  "boom",
+			},
+			want: &rerrors.ServiceFault{},
+		},
+		{
+			name: "unrecognized error becomes ServiceFault",
+			err:  errStub("network reset"),
+			want: &rerrors.ServiceFault{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyError(tt.err, "some op")
+			switch tt.want.(type) {
+			case *rerrors.TooManyRequestsError:
+				if _, ok := got.(*rerrors.TooManyRequestsError); !ok {
+					t.Errorf("classifyError() = %T, want *rerrors.TooManyRequestsError", got)
+				}
+			case *rerrors.UserError:
+				if _, ok := got.(*rerrors.UserError); !ok {
+					t.Errorf("classifyError() = %T, want *rerrors.UserError", got)
+				}
+			case *rerrors.ServiceFault:
+				if _, ok := got.(*rerrors.ServiceFault); !ok {
+					t.Errorf("classifyError() = %T, want *rerrors.ServiceFault", got)
+				}
+			}
+		})
+	}
+}
+
+type errStub string
+
+func (e errStub) Error() string { return string(e) }
@@ -0,0 +1,87 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// ESLint runs eslint against changed JavaScript/TypeScript files.
+type ESLint struct{}
+
+func (ESLint) Name() string { return "eslint" }
+
+func (ESLint) Applies(files []string) bool {
+	for _, f := range files {
+		switch filepath.Ext(f) {
+		case ".js", ".jsx", ".ts", ".tsx":
+			return true
+		}
+	}
+	return false
+}
+
+type eslintResult struct {
+	FilePath string `json:"filePath"`
+	Messages []struct {
+		RuleID   string `json:"ruleId"`
+		Severity int    `json:"severity"`
+		Message  string `json:"message"`
+		Line     int    `json:"line"`
+	} `json:"messages"`
+}
+
+func (ESLint) Run(ctx context.Context, workdir string, files []string) ([]LintFinding, error) {
+	var jsFiles []string
+	for _, f := range files {
+		switch filepath.Ext(f) {
+		case ".js", ".jsx", ".ts", ".tsx":
+			jsFiles = append(jsFiles, f)
+		}
+	}
+
+	args := append([]string{"--format", "json"}, jsFiles...)
+	cmd := exec.CommandContext(ctx, "eslint", args...)
+	cmd.Dir = workdir
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	// eslint exits non-zero when it finds issues; only treat a decode
+	// failure as a real execution error.
+	_ = cmd.Run()
+
+	var out []eslintResult
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, fmt.Errorf("parse eslint output: %w", err)
+	}
+
+	var findings []LintFinding
+	for _, result := range out {
+		file := strings.TrimPrefix(result.FilePath, workdir+"/")
+		for _, msg := range result.Messages {
+			severity := "info"
+			if msg.Severity == 2 {
+				severity = "error"
+			} else if msg.Severity == 1 {
+				severity = "warning"
+			}
+			findings = append(findings, LintFinding{
+				File:     file,
+				Line:     msg.Line,
+				Rule:     msg.RuleID,
+				Severity: severity,
+				Message:  msg.Message,
+			})
+		}
+	}
+	return findings, nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GolangCILint runs golangci-lint against changed Go files.
+type GolangCILint struct{}
+
+func (GolangCILint) Name() string { return "golangci-lint" }
+
+func (GolangCILint) Applies(files []string) bool {
+	for _, f := range files {
+		if filepath.Ext(f) == ".go" {
+			return true
+		}
+	}
+	return false
+}
+
+type golangciOutput struct {
+	Issues []struct {
+		FromLinter string `json:"FromLinter"`
+		Text       string `json:"Text"`
+		Severity   string `json:"Severity"`
+		Pos        struct {
+			Filename string `json:"Filename"`
+			Line     int    `json:"Line"`
+		} `json:"Pos"`
+	} `json:"Issues"`
+}
+
+func (GolangCILint) Run(ctx context.Context, workdir string, _ []string) ([]LintFinding, error) {
+	cmd := exec.CommandContext(ctx, "golangci-lint", "run", "--out-format", "json", "./...")
+	cmd.Dir = workdir
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	// golangci-lint exits non-zero when it finds issues, which is expected;
+	// only a JSON decode failure indicates a real execution problem.
+	_ = cmd.Run()
+
+	var out golangciOutput
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, fmt.Errorf("parse golangci-lint output: %w", err)
+	}
+
+	findings := make([]LintFinding, 0, len(out.Issues))
+	for _, issue := range out.Issues {
+		findings = append(findings, LintFinding{
+			File:     strings.TrimPrefix(issue.Pos.Filename, workdir+"/"),
+			Line:     issue.Pos.Line,
+			Rule:     issue.FromLinter,
+			Severity: issue.Severity,
+			Message:  issue.Text,
+		})
+	}
+	return findings, nil
+}
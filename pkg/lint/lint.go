@@ -0,0 +1,36 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package lint runs deterministic static analyzers over a PR's changed
+// files before the AI review pass, so the model can dedupe, prioritize, or
+// expand on findings that a real linter already caught rather than
+// re-deriving the same style issues from scratch.
+package lint
+
+import "context"
+
+// LintFinding is a single issue reported by a Linter, normalized to the
+// same file/line/severity shape regardless of which tool produced it.
+type LintFinding struct {
+	File     string
+	Line     int
+	Rule     string
+	Severity string
+	Message  string
+}
+
+// Linter runs a single static analysis tool against a checked-out PR head.
+type Linter interface {
+	// Name identifies the linter (e.g. "golangci-lint", "ruff").
+	Name() string
+
+	// Applies reports whether this linter should run at all, given the
+	// full list of changed file paths in the PR.
+	Applies(files []string) bool
+
+	// Run executes the linter against workdir (a checkout of the PR head)
+	// and returns findings across files.
+	Run(ctx context.Context, workdir string, files []string) ([]LintFinding, error)
+}
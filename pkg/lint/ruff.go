@@ -0,0 +1,75 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package lint
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Ruff runs the ruff Python linter against changed Python files.
+type Ruff struct{}
+
+func (Ruff) Name() string { return "ruff" }
+
+func (Ruff) Applies(files []string) bool {
+	for _, f := range files {
+		if filepath.Ext(f) == ".py" {
+			return true
+		}
+	}
+	return false
+}
+
+type ruffFinding struct {
+	Filename string `json:"filename"`
+	Code     string `json:"code"`
+	Message  string `json:"message"`
+	Location struct {
+		Row int `json:"row"`
+	} `json:"location"`
+}
+
+func (Ruff) Run(ctx context.Context, workdir string, files []string) ([]LintFinding, error) {
+	pyFiles := make([]string, 0, len(files))
+	for _, f := range files {
+		if filepath.Ext(f) == ".py" {
+			pyFiles = append(pyFiles, f)
+		}
+	}
+
+	args := append([]string{"check", "--output-format", "json"}, pyFiles...)
+	cmd := exec.CommandContext(ctx, "ruff", args...)
+	cmd.Dir = workdir
+
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	// ruff exits non-zero when it finds issues; only treat a decode
+	// failure as a real execution error.
+	_ = cmd.Run()
+
+	var out []ruffFinding
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return nil, fmt.Errorf("parse ruff output: %w", err)
+	}
+
+	findings := make([]LintFinding, 0, len(out))
+	for _, f := range out {
+		findings = append(findings, LintFinding{
+			File:     strings.TrimPrefix(f.Filename, workdir+"/"),
+			Line:     f.Location.Row,
+			Rule:     f.Code,
+			Severity: "warning",
+			Message:  f.Message,
+		})
+	}
+	return findings, nil
+}
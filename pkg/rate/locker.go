@@ -0,0 +1,54 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rate
+
+import (
+	"context"
+	"sync"
+)
+
+// Locker provides mutual exclusion keyed by an opaque string, used to
+// ensure only one review is in flight for a given PR at a time.
+type Locker interface {
+	// TryLock attempts to acquire the lock for key without blocking,
+	// returning false if it's already held.
+	TryLock(ctx context.Context, key string) (bool, error)
+
+	// Unlock releases the lock for key.
+	Unlock(ctx context.Context, key string) error
+}
+
+// InMemoryLocker is the default Locker, suitable for a single reviewer
+// process. Use RedisLocker when multiple reviewer instances need to
+// coordinate.
+type InMemoryLocker struct {
+	mu     sync.Mutex
+	locked map[string]bool
+}
+
+// NewInMemoryLocker creates an empty InMemoryLocker.
+func NewInMemoryLocker() *InMemoryLocker {
+	return &InMemoryLocker{locked: make(map[string]bool)}
+}
+
+func (l *InMemoryLocker) TryLock(_ context.Context, key string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.locked[key] {
+		return false, nil
+	}
+	l.locked[key] = true
+	return true, nil
+}
+
+func (l *InMemoryLocker) Unlock(_ context.Context, key string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	delete(l.locked, key)
+	return nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rate
+
+import (
+	"context"
+	"testing"
+)
+
+func TestInMemoryLocker(t *testing.T) {
+	ctx := context.Background()
+	l := NewInMemoryLocker()
+
+	acquired, err := l.TryLock(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("TryLock() error = %v", err)
+	}
+	if !acquired {
+		t.Fatal("expected first TryLock to succeed")
+	}
+
+	acquired, err = l.TryLock(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("TryLock() error = %v", err)
+	}
+	if acquired {
+		t.Fatal("expected a second TryLock on the same key to fail while held")
+	}
+
+	// A different key isn't affected by pr-1's lock.
+	acquired, err = l.TryLock(ctx, "pr-2")
+	if err != nil {
+		t.Fatalf("TryLock() error = %v", err)
+	}
+	if !acquired {
+		t.Fatal("expected TryLock on a distinct key to succeed")
+	}
+
+	if err := l.Unlock(ctx, "pr-1"); err != nil {
+		t.Fatalf("Unlock() error = %v", err)
+	}
+
+	acquired, err = l.TryLock(ctx, "pr-1")
+	if err != nil {
+		t.Fatalf("TryLock() error = %v", err)
+	}
+	if !acquired {
+		t.Fatal("expected TryLock to succeed again after Unlock")
+	}
+}
@@ -0,0 +1,65 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package rate provides the throttling primitives used to keep a burst of
+// webhook events for the same PR from triggering more review work (LLM
+// calls, not just the final GitHub API post) than intended.
+package rate
+
+import (
+	"sync"
+	"time"
+)
+
+// SlidingWindowLimiter throttles how often an event may fire for a given
+// key within a rolling time window, e.g. limiting how many times a PR can
+// trigger a review within a minute regardless of how many webhook events
+// land in that window.
+type SlidingWindowLimiter struct {
+	window    time.Duration
+	maxEvents int
+
+	mu     sync.Mutex
+	events map[string][]time.Time
+}
+
+// NewSlidingWindowLimiter creates a limiter allowing at most maxEvents per
+// key within window.
+func NewSlidingWindowLimiter(window time.Duration, maxEvents int) *SlidingWindowLimiter {
+	return &SlidingWindowLimiter{
+		window:    window,
+		maxEvents: maxEvents,
+		events:    make(map[string][]time.Time),
+	}
+}
+
+// Window returns the rolling window this limiter enforces, so a caller
+// that gets rejected can report how long the caller should back off.
+func (l *SlidingWindowLimiter) Window() time.Duration {
+	return l.window
+}
+
+// Allow reports whether an event for key is permitted at now, recording it
+// if so. Events older than the window are pruned on every call.
+func (l *SlidingWindowLimiter) Allow(key string, now time.Time) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	cutoff := now.Add(-l.window)
+	kept := l.events[key][:0]
+	for _, t := range l.events[key] {
+		if t.After(cutoff) {
+			kept = append(kept, t)
+		}
+	}
+
+	if len(kept) >= l.maxEvents {
+		l.events[key] = kept
+		return false
+	}
+
+	l.events[key] = append(kept, now)
+	return true
+}
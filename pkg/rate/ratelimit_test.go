@@ -0,0 +1,65 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rate
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSlidingWindowLimiterAllow(t *testing.T) {
+	base := time.Unix(0, 0)
+
+	tests := []struct {
+		name      string
+		maxEvents int
+		calls     []time.Duration // offsets from base at which Allow is called
+		want      []bool
+	}{
+		{
+			name:      "allows up to the limit then blocks",
+			maxEvents: 2,
+			calls:     []time.Duration{0, time.Second, 2 * time.Second},
+			want:      []bool{true, true, false},
+		},
+		{
+			name:      "allows again once the window slides past the oldest event",
+			maxEvents: 1,
+			calls:     []time.Duration{0, 30 * time.Second, time.Minute + time.Second},
+			want:      []bool{true, false, true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewSlidingWindowLimiter(time.Minute, tt.maxEvents)
+			for i, offset := range tt.calls {
+				got := l.Allow("pr-1", base.Add(offset))
+				if got != tt.want[i] {
+					t.Errorf("call %d: Allow() = %v, want %v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSlidingWindowLimiterKeysAreIndependent(t *testing.T) {
+	l := NewSlidingWindowLimiter(time.Minute, 1)
+
+	if !l.Allow("pr-1", time.Unix(0, 0)) {
+		t.Fatal("expected first event for pr-1 to be allowed")
+	}
+	if !l.Allow("pr-2", time.Unix(0, 0)) {
+		t.Fatal("expected first event for pr-2 to be allowed, since it's a distinct key from pr-1")
+	}
+}
+
+func TestSlidingWindowLimiterWindow(t *testing.T) {
+	l := NewSlidingWindowLimiter(90*time.Second, 1)
+	if got := l.Window(); got != 90*time.Second {
+		t.Errorf("Window() = %v, want %v", got, 90*time.Second)
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rate
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// unlockScript deletes the lock key only if it still holds the token this
+// call to TryLock set, so an Unlock that arrives after the TTL has
+// already expired and handed the key to a new holder can't delete that
+// new holder's active lock.
+const unlockScript = `
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+else
+	return 0
+end
+`
+
+// RedisLocker is a Locker backed by Redis, letting multiple reviewer
+// instances share the same "one review in flight per PR" guarantee.
+type RedisLocker struct {
+	client *redis.Client
+
+	// TTL bounds how long a lock is held before it's released
+	// automatically, in case an instance crashes mid-review. Defaults to
+	// 5 minutes.
+	TTL time.Duration
+
+	// tokens remembers the random value this TryLock call wrote for each
+	// key currently held, so Unlock can do a compare-and-delete instead
+	// of blindly removing whatever's there.
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+// NewRedisLocker creates a RedisLocker using client.
+func NewRedisLocker(client *redis.Client) *RedisLocker {
+	return &RedisLocker{client: client, TTL: 5 * time.Minute, tokens: make(map[string]string)}
+}
+
+func (l *RedisLocker) TryLock(ctx context.Context, key string) (bool, error) {
+	token, err := randomToken()
+	if err != nil {
+		return false, fmt.Errorf("generate lock token: %w", err)
+	}
+
+	ok, err := l.client.SetNX(ctx, l.lockKey(key), token, l.TTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("acquire redis lock %s: %w", key, err)
+	}
+	if ok {
+		l.mu.Lock()
+		l.tokens[key] = token
+		l.mu.Unlock()
+	}
+	return ok, nil
+}
+
+// Unlock releases key only if it still holds the token this instance's
+// TryLock set. If the lock's TTL already expired and a different holder
+// has since acquired it, Unlock leaves that holder's lock alone instead
+// of deleting it out from under them.
+func (l *RedisLocker) Unlock(ctx context.Context, key string) error {
+	l.mu.Lock()
+	token := l.tokens[key]
+	delete(l.tokens, key)
+	l.mu.Unlock()
+
+	if err := l.client.Eval(ctx, unlockScript, []string{l.lockKey(key)}, token).Err(); err != nil {
+		return fmt.Errorf("release redis lock %s: %w", key, err)
+	}
+	return nil
+}
+
+func (l *RedisLocker) lockKey(key string) string {
+	return "code-reviewer:lock:" + key
+}
+
+// randomToken generates an opaque per-lock value so Unlock can tell
+// whether it's still the current holder before deleting.
+func randomToken() (string, error) {
+	buf := make([]byte, 16)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("generate random token: %w", err)
+	}
+	return hex.EncodeToString(buf), nil
+}
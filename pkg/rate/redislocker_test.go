@@ -0,0 +1,41 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rate
+
+// These cover the pure-Go pieces of the compare-and-delete fix
+// (randomToken, lockKey) that don't require a live Redis server. The
+// RedisLocker TryLock/Unlock round trip needs a live Redis server and is
+// not tested here; TestInMemoryLocker covers the same Locker contract for
+// InMemoryLocker only.
+
+import "testing"
+
+func TestRandomTokenIsUniqueAndNonEmpty(t *testing.T) {
+	a, err := randomToken()
+	if err != nil {
+		t.Fatalf("randomToken() error = %v", err)
+	}
+	b, err := randomToken()
+	if err != nil {
+		t.Fatalf("randomToken() error = %v", err)
+	}
+
+	if a == "" || b == "" {
+		t.Fatal("randomToken() returned an empty string")
+	}
+	if a == b {
+		t.Fatalf("randomToken() returned the same value twice: %q", a)
+	}
+}
+
+func TestRedisLockerLockKeyIsNamespaced(t *testing.T) {
+	l := &RedisLocker{}
+	got := l.lockKey("acme/widgets/42")
+	want := "code-reviewer:lock:acme/widgets/42"
+	if got != want {
+		t.Errorf("lockKey() = %q, want %q", got, want)
+	}
+}
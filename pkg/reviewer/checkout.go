@@ -0,0 +1,68 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reviewer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+)
+
+// checkoutPRHead clones owner/repo into a temporary directory and checks
+// out sha, returning the directory so linters can run against real files
+// on disk. The caller is responsible for removing the returned directory.
+// token authenticates the clone (see ghclient.Client.CloneCredential) so
+// this works against private repos; pass an empty string for a public
+// repo or a client with no credential.
+func checkoutPRHead(ctx context.Context, owner, repo, sha, token string) (string, error) {
+	dir, err := os.MkdirTemp("", "code-reviewer-checkout-*")
+	if err != nil {
+		return "", fmt.Errorf("create checkout dir: %w", err)
+	}
+
+	url := cloneURL(owner, repo, token)
+
+	// run's error doesn't include args or output verbatim when a
+	// credential is in play, since git echoes the fetch URL (and any
+	// auth failure message) right back into CombinedOutput.
+	run := func(redact bool, args ...string) error {
+		cmd := exec.CommandContext(ctx, "git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			if redact {
+				return fmt.Errorf("git %s: %w", args[0], err)
+			}
+			return fmt.Errorf("git %v: %w: %s", args, err, out)
+		}
+		return nil
+	}
+
+	if err := run(false, "init", "-q"); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	if err := run(token != "", "fetch", "-q", "--depth", "1", url, sha); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("fetch %s: %w", sha, err)
+	}
+	if err := run(false, "checkout", "-q", "FETCH_HEAD"); err != nil {
+		os.RemoveAll(dir)
+		return "", fmt.Errorf("checkout %s: %w", sha, err)
+	}
+
+	return dir, nil
+}
+
+// cloneURL builds the HTTPS clone URL for owner/repo, embedding token as
+// an x-access-token credential when set so private repos can be cloned;
+// see ghclient.Client.CloneCredential.
+func cloneURL(owner, repo, token string) string {
+	if token == "" {
+		return fmt.Sprintf("https://github.com/%s/%s.git", owner, repo)
+	}
+	return fmt.Sprintf("https://x-access-token:%s@github.com/%s/%s.git", token, owner, repo)
+}
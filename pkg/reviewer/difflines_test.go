@@ -0,0 +1,53 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reviewer
+
+import "testing"
+
+const sampleDiff = `diff --git a/pkg/foo.go b/pkg/foo.go
+index abc1234..def5678 100644
+--- a/pkg/foo.go
++++ b/pkg/foo.go
+@@ -10,3 +10,5 @@ func Foo() {
+ 	a := 1
++	b := 2
++	c := 3
+ 	return a
+ }
+`
+
+func TestDiffLinesContains(t *testing.T) {
+	dl := newDiffLines(sampleDiff)
+
+	tests := []struct {
+		name string
+		file string
+		line int
+		want bool
+	}{
+		{"added line in range", "pkg/foo.go", 11, true},
+		{"context line at start of hunk", "pkg/foo.go", 10, true},
+		{"context line after the added lines", "pkg/foo.go", 13, true},
+		{"line before the hunk", "pkg/foo.go", 1, false},
+		{"line after the hunk", "pkg/foo.go", 100, false},
+		{"unknown file", "pkg/other.go", 11, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := dl.contains(tt.file, tt.line); got != tt.want {
+				t.Errorf("contains(%q, %d) = %v, want %v", tt.file, tt.line, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDiffLinesContainsEmptyDiff(t *testing.T) {
+	dl := newDiffLines("")
+	if dl.contains("pkg/foo.go", 1) {
+		t.Error("contains() on an empty diff should always be false")
+	}
+}
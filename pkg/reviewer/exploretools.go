@@ -0,0 +1,314 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reviewer
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"sync"
+
+	"chainguard.dev/driftless/pkg/evals"
+	"chainguard.dev/driftless/pkg/executor/claudeexecutor"
+	"chainguard.dev/driftless/pkg/executor/googleexecutor"
+	"chainguard.dev/driftless/pkg/toolcall/claudetool"
+	"chainguard.dev/driftless/pkg/toolcall/googletool"
+	"github.com/anthropics/anthropic-sdk-go"
+	ghclient "github.com/example/code-reviewer/pkg/github"
+	gh "github.com/google/go-github/v68/github"
+	"google.golang.org/genai"
+)
+
+// gitBlameTool, listDirTool, and searchCodeTool describe the repo-
+// exploration tools available alongside read_file: enough for the model
+// to justify a suggestion with "this was introduced in commit X" or find
+// other call sites before proposing a rename.
+var (
+	gitBlameTool = toolDescriptor{
+		Name:        "git_blame",
+		Description: "Show the commit SHA, author, and date that last touched each line in a range of a file at the PR head",
+		Params: []toolParam{
+			{Name: "path", Type: "string", Description: "File path relative to repository root", Required: true},
+			{Name: "line_start", Type: "integer", Description: "First line number to blame (1-indexed)", Required: true},
+			{Name: "line_end", Type: "integer", Description: "Last line number to blame (1-indexed, inclusive)", Required: true},
+		},
+	}
+
+	listDirTool = toolDescriptor{
+		Name:        "list_dir",
+		Description: "List the entries of a directory in the PR's repository at the PR head, with type and size",
+		Params: []toolParam{
+			{Name: "path", Type: "string", Description: "Directory path relative to repository root", Required: true},
+		},
+	}
+
+	searchCodeTool = toolDescriptor{
+		Name:        "search_code",
+		Description: "Search the repository's code with GitHub code search, e.g. to find other call sites before proposing a rename. Results are not pinned to the PR head commit",
+		Params: []toolParam{
+			{Name: "query", Type: "string", Description: "Search query, using GitHub code search syntax", Required: true},
+			{Name: "path_glob", Type: "string", Description: "Restrict results to paths matching this glob, e.g. pkg/**/*.go", Required: false},
+			{Name: "max_results", Type: "integer", Description: "Maximum number of results to return (default 10)", Required: false},
+		},
+	}
+)
+
+// blameRunner checks out the PR head the first time git_blame is invoked
+// and reuses that checkout for the rest of the review, since GitHub's
+// REST API doesn't expose blame and a full clone per call would be
+// wasteful if the model asks for blame on several files.
+type blameRunner struct {
+	github           *ghclient.Client
+	owner, repo, sha string
+
+	once sync.Once
+	dir  string
+	err  error
+}
+
+func (b *blameRunner) blame(ctx context.Context, path string, lineStart, lineEnd int) (string, error) {
+	b.once.Do(func() {
+		token, err := b.github.CloneCredential(ctx)
+		if err != nil {
+			b.err = fmt.Errorf("mint clone credential: %w", err)
+			return
+		}
+		b.dir, b.err = checkoutPRHead(ctx, b.owner, b.repo, b.sha, token)
+	})
+	if b.err != nil {
+		return "", fmt.Errorf("checkout for blame: %w", b.err)
+	}
+
+	lineRange := fmt.Sprintf("%d,%d", lineStart, lineEnd)
+	cmd := exec.CommandContext(ctx, "git", "blame", "-L", lineRange, "--date=short", "--", path)
+	cmd.Dir = b.dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		return "", fmt.Errorf("git blame %s: %w: %s", path, err, out)
+	}
+	return string(out), nil
+}
+
+// cleanup removes the checkout, if one was made. Safe to call even if
+// blame was never invoked.
+func (b *blameRunner) cleanup() {
+	if b.dir != "" {
+		os.RemoveAll(b.dir)
+	}
+}
+
+// claudeGitBlameTool creates a Claude tool for blaming a line range.
+func (r *Reviewer) claudeGitBlameTool(blame *blameRunner) claudeexecutor.ToolMetadata[*ReviewResult] {
+	return claudeexecutor.ToolMetadata[*ReviewResult]{
+		Definition: gitBlameTool.claudeDefinition(),
+		Handler: func(ctx context.Context, toolUse anthropic.ToolUseBlock,
+			trace *evals.Trace[*ReviewResult], result **ReviewResult) map[string]any {
+
+			params, errResp := claudetool.NewParams(toolUse)
+			if errResp != nil {
+				return errResp
+			}
+			path, errResp := claudetool.Param[string](params, "path")
+			if errResp != nil {
+				return errResp
+			}
+			lineStart, errResp := claudetool.Param[int](params, "line_start")
+			if errResp != nil {
+				return errResp
+			}
+			lineEnd, errResp := claudetool.Param[int](params, "line_end")
+			if errResp != nil {
+				return errResp
+			}
+
+			out, err := blame.blame(ctx, path, lineStart, lineEnd)
+			if err != nil {
+				return claudetool.Error("failed to blame %s:%d-%d: %v", path, lineStart, lineEnd, err)
+			}
+
+			return map[string]any{"path": path, "blame": out}
+		},
+	}
+}
+
+// geminiGitBlameTool creates a Gemini tool for blaming a line range.
+func (r *Reviewer) geminiGitBlameTool(blame *blameRunner) googleexecutor.ToolMetadata[*ReviewResult] {
+	return googleexecutor.ToolMetadata[*ReviewResult]{
+		Definition: gitBlameTool.geminiDefinition(),
+		Handler: func(ctx context.Context, call *genai.FunctionCall,
+			trace *evals.Trace[*ReviewResult], result **ReviewResult) *genai.FunctionResponse {
+
+			path, errResp := googletool.Param[string](call, "path")
+			if errResp != nil {
+				return errResp
+			}
+			lineStart, errResp := googletool.Param[int](call, "line_start")
+			if errResp != nil {
+				return errResp
+			}
+			lineEnd, errResp := googletool.Param[int](call, "line_end")
+			if errResp != nil {
+				return errResp
+			}
+
+			out, err := blame.blame(ctx, path, lineStart, lineEnd)
+			if err != nil {
+				return googletool.Error(call, "failed to blame %s:%d-%d: %v", path, lineStart, lineEnd, err)
+			}
+
+			return &genai.FunctionResponse{
+				ID:       call.ID,
+				Name:     call.Name,
+				Response: map[string]any{"path": path, "blame": out},
+			}
+		},
+	}
+}
+
+// claudeListDirTool creates a Claude tool for listing a directory.
+func (r *Reviewer) claudeListDirTool(owner, repo, sha string) claudeexecutor.ToolMetadata[*ReviewResult] {
+	return claudeexecutor.ToolMetadata[*ReviewResult]{
+		Definition: listDirTool.claudeDefinition(),
+		Handler: func(ctx context.Context, toolUse anthropic.ToolUseBlock,
+			trace *evals.Trace[*ReviewResult], result **ReviewResult) map[string]any {
+
+			params, errResp := claudetool.NewParams(toolUse)
+			if errResp != nil {
+				return errResp
+			}
+			path, errResp := claudetool.Param[string](params, "path")
+			if errResp != nil {
+				return errResp
+			}
+
+			entries, err := r.github.ListDir(ctx, owner, repo, path, sha)
+			if err != nil {
+				return claudetool.Error("failed to list directory %s: %v", path, err)
+			}
+
+			return map[string]any{"path": path, "entries": dirEntryList(entries)}
+		},
+	}
+}
+
+// geminiListDirTool creates a Gemini tool for listing a directory.
+func (r *Reviewer) geminiListDirTool(owner, repo, sha string) googleexecutor.ToolMetadata[*ReviewResult] {
+	return googleexecutor.ToolMetadata[*ReviewResult]{
+		Definition: listDirTool.geminiDefinition(),
+		Handler: func(ctx context.Context, call *genai.FunctionCall,
+			trace *evals.Trace[*ReviewResult], result **ReviewResult) *genai.FunctionResponse {
+
+			path, errResp := googletool.Param[string](call, "path")
+			if errResp != nil {
+				return errResp
+			}
+
+			entries, err := r.github.ListDir(ctx, owner, repo, path, sha)
+			if err != nil {
+				return googletool.Error(call, "failed to list directory %s: %v", path, err)
+			}
+
+			return &genai.FunctionResponse{
+				ID:       call.ID,
+				Name:     call.Name,
+				Response: map[string]any{"path": path, "entries": dirEntryList(entries)},
+			}
+		},
+	}
+}
+
+// claudeSearchCodeTool creates a Claude tool for searching the repo's code.
+func (r *Reviewer) claudeSearchCodeTool(owner, repo string) claudeexecutor.ToolMetadata[*ReviewResult] {
+	return claudeexecutor.ToolMetadata[*ReviewResult]{
+		Definition: searchCodeTool.claudeDefinition(),
+		Handler: func(ctx context.Context, toolUse anthropic.ToolUseBlock,
+			trace *evals.Trace[*ReviewResult], result **ReviewResult) map[string]any {
+
+			params, errResp := claudetool.NewParams(toolUse)
+			if errResp != nil {
+				return errResp
+			}
+			query, errResp := claudetool.Param[string](params, "query")
+			if errResp != nil {
+				return errResp
+			}
+			pathGlob, _ := claudetool.Param[string](params, "path_glob")
+			maxResults, _ := claudetool.Param[int](params, "max_results")
+			if maxResults == 0 {
+				maxResults = defaultSearchCodeMaxResults
+			}
+
+			results, err := r.github.SearchCode(ctx, owner, repo, query, pathGlob, maxResults)
+			if err != nil {
+				return claudetool.Error("failed to search code for %q: %v", query, err)
+			}
+
+			return map[string]any{"results": codeResultList(results)}
+		},
+	}
+}
+
+// geminiSearchCodeTool creates a Gemini tool for searching the repo's code.
+func (r *Reviewer) geminiSearchCodeTool(owner, repo string) googleexecutor.ToolMetadata[*ReviewResult] {
+	return googleexecutor.ToolMetadata[*ReviewResult]{
+		Definition: searchCodeTool.geminiDefinition(),
+		Handler: func(ctx context.Context, call *genai.FunctionCall,
+			trace *evals.Trace[*ReviewResult], result **ReviewResult) *genai.FunctionResponse {
+
+			query, errResp := googletool.Param[string](call, "query")
+			if errResp != nil {
+				return errResp
+			}
+			pathGlob, _ := googletool.Param[string](call, "path_glob")
+			maxResults, _ := googletool.Param[int](call, "max_results")
+			if maxResults == 0 {
+				maxResults = defaultSearchCodeMaxResults
+			}
+
+			results, err := r.github.SearchCode(ctx, owner, repo, query, pathGlob, maxResults)
+			if err != nil {
+				return googletool.Error(call, "failed to search code for %q: %v", query, err)
+			}
+
+			return &genai.FunctionResponse{
+				ID:       call.ID,
+				Name:     call.Name,
+				Response: map[string]any{"results": codeResultList(results)},
+			}
+		},
+	}
+}
+
+// defaultSearchCodeMaxResults is used when the model omits max_results.
+const defaultSearchCodeMaxResults = 10
+
+// dirEntryList converts go-github's directory listing into the plain
+// maps the tool response schemas expect.
+func dirEntryList(entries []*gh.RepositoryContent) []map[string]any {
+	out := make([]map[string]any, len(entries))
+	for i, e := range entries {
+		out[i] = map[string]any{
+			"name": e.GetName(),
+			"type": e.GetType(),
+			"size": e.GetSize(),
+		}
+	}
+	return out
+}
+
+// codeResultList converts go-github's code search results into the plain
+// maps the tool response schemas expect.
+func codeResultList(results []*gh.CodeResult) []map[string]any {
+	out := make([]map[string]any, len(results))
+	for i, res := range results {
+		out[i] = map[string]any{
+			"path":       res.GetPath(),
+			"repository": res.GetRepository().GetFullName(),
+		}
+	}
+	return out
+}
@@ -10,10 +10,58 @@ import (
 	"encoding/json"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"chainguard.dev/driftless/pkg/judge"
+	"github.com/example/code-reviewer/pkg/cache"
+	rerrors "github.com/example/code-reviewer/pkg/errors"
 )
 
+// JudgeMode selects how the judge compares suggestions.
+type JudgeMode string
+
+const (
+	// ModeStandalone scores each suggestion independently against the rubric.
+	// This is the default and matches the original judge behavior.
+	ModeStandalone JudgeMode = "standalone"
+
+	// ModePairwise groups suggestions targeting the same file and line range
+	// and asks the judge to pick the better one, dropping the loser.
+	ModePairwise JudgeMode = "pairwise"
+
+	// ModeReference compares each suggestion against a reference review
+	// (e.g. a prior run or a human review) passed as JudgeConfig.Reference.
+	ModeReference JudgeMode = "reference"
+)
+
+// JudgeCriterion is a single dimension of the judging rubric.
+type JudgeCriterion struct {
+	// Name identifies the criterion (e.g. "accuracy", "actionability").
+	Name string
+
+	// Description explains what the judge should look for on this dimension.
+	Description string
+
+	// Weight determines this criterion's contribution to the final score.
+	// Weights are normalized, so they don't need to sum to 1.0.
+	Weight float64
+
+	// MinScore is the minimum acceptable score (0.0-1.0) on this criterion
+	// alone. A suggestion failing any criterion's MinScore is filtered out
+	// regardless of its weighted average.
+	MinScore float64
+}
+
+// defaultRubric is used when JudgeConfig.Rubric is empty, preserving the
+// original single-criterion behavior.
+var defaultRubric = []JudgeCriterion{
+	{
+		Name:        "overall",
+		Description: judgeCriterion,
+		Weight:      1.0,
+	},
+}
+
 // JudgeConfig contains configuration for the judge evaluation.
 type JudgeConfig struct {
 	// Enabled determines whether judge evaluation is performed.
@@ -22,9 +70,27 @@ type JudgeConfig struct {
 	// Model is the model to use for judging (e.g., "claude-sonnet-4-5@20251101").
 	Model string
 
-	// MinScore is the minimum score (0.0-1.0) for a suggestion to be included.
-	// Suggestions below this threshold are filtered out.
+	// MinScore is the minimum weighted score (0.0-1.0) for a suggestion to
+	// be included. Suggestions below this threshold are filtered out.
 	MinScore float64
+
+	// Mode selects the judging strategy. Defaults to ModeStandalone.
+	Mode JudgeMode
+
+	// Rubric breaks scoring into weighted sub-criteria. If empty, a single
+	// "overall" criterion matching the original scoring behavior is used.
+	Rubric []JudgeCriterion
+
+	// Reference is an optional prior review (e.g. from an earlier run or a
+	// human reviewer) used in ModeReference to judge suggestions against a
+	// known-good answer instead of evaluating them in isolation.
+	Reference *ReviewResult
+
+	// Cache, if set, is checked before calling the judge model and
+	// populated after, keyed by cache.JudgeVerdictKey(suggestion,
+	// criterion, model). A rerun of the same suggestion against the same
+	// rubric and model skips the judge call entirely.
+	Cache cache.Cache
 }
 
 // DefaultJudgeConfig returns the default judge configuration.
@@ -33,6 +99,7 @@ func DefaultJudgeConfig() JudgeConfig {
 		Enabled:  false,
 		Model:    "gemini-2.5-flash", // Use a fast model for judging
 		MinScore: 0.5,                // Filter out suggestions scoring below 0.5
+		Mode:     ModeStandalone,
 	}
 }
 
@@ -42,6 +109,11 @@ type JudgedSuggestion struct {
 	Score       float64
 	Reasoning   string
 	Improvement []string
+
+	// SubScores holds the per-criterion score (0.0-1.0) keyed by
+	// JudgeCriterion.Name, for every criterion in the rubric used (the
+	// default rubric's single "overall" criterion included).
+	SubScores map[string]float64
 }
 
 // judgeCriterion defines what makes a good code review suggestion.
@@ -73,42 +145,45 @@ func JudgeSuggestions(ctx context.Context, projectID, location string, config Ju
 		return result, nil
 	}
 
-	log := slog.With("component", "judge", "model", config.Model)
+	mode := config.Mode
+	if mode == "" {
+		mode = ModeStandalone
+	}
+
+	rubric := config.Rubric
+	if len(rubric) == 0 {
+		rubric = defaultRubric
+	}
+
+	log := slog.With("component", "judge", "model", config.Model, "mode", mode)
 	log.Info("creating judge instance")
 
 	// Create judge instance
 	j, err := judge.NewVertex(ctx, projectID, location, config.Model)
 	if err != nil {
-		return nil, fmt.Errorf("create judge: %w", err)
+		return nil, classifyVertexError(err, "create judge")
 	}
 
-	log.Info("evaluating suggestions", "count", len(suggestions), "min_score", config.MinScore)
-
-	var result []JudgedSuggestion
-	var filtered int
-
-	for i, s := range suggestions {
-		// Format the suggestion for evaluation
-		suggestionJSON, err := json.MarshalIndent(s, "", "  ")
+	candidates := suggestions
+	if mode == ModePairwise {
+		candidates, err = resolvePairwise(ctx, j, log, suggestions)
 		if err != nil {
-			return nil, fmt.Errorf("marshal suggestion %d: %w", i, err)
+			return nil, fmt.Errorf("pairwise judging: %w", err)
 		}
+	}
 
-		// Create judge request
-		req := &judge.Request{
-			Mode:         judge.StandaloneMode,
-			ActualAnswer: string(suggestionJSON),
-			Criterion:    judgeCriterion,
-		}
+	log.Info("evaluating suggestions", "count", len(candidates), "min_score", config.MinScore)
+
+	var result []JudgedSuggestion
+	var filtered int
 
-		// Execute judgment
-		judgement, err := j.Judge(ctx, req)
+	for i, s := range candidates {
+		js, keep, err := scoreSuggestion(ctx, j, config.Cache, config.Model, rubric, mode, config.Reference, s)
 		if err != nil {
 			log.Warn("judge evaluation failed, including suggestion anyway",
 				"suggestion", i,
 				"file", s.File,
 				"error", err)
-			// Include suggestion on judge failure
 			result = append(result, JudgedSuggestion{
 				Suggestion: s,
 				Score:      1.0,
@@ -122,25 +197,19 @@ func JudgeSuggestions(ctx context.Context, projectID, location string, config Ju
 			"file", s.File,
 			"line", s.LineEnd,
 			"severity", s.Severity,
-			"score", judgement.Score)
+			"score", js.Score)
 
-		// Filter by score threshold
-		if judgement.Score < config.MinScore {
+		if !keep || js.Score < config.MinScore {
 			filtered++
 			log.Info("filtering low-quality suggestion",
 				"file", s.File,
-				"score", judgement.Score,
+				"score", js.Score,
 				"threshold", config.MinScore,
-				"reasoning", judgement.Reasoning)
+				"reasoning", js.Reasoning)
 			continue
 		}
 
-		result = append(result, JudgedSuggestion{
-			Suggestion:  s,
-			Score:       judgement.Score,
-			Reasoning:   judgement.Reasoning,
-			Improvement: judgement.Suggestions,
-		})
+		result = append(result, js)
 	}
 
 	log.Info("judge evaluation complete",
@@ -151,6 +220,250 @@ func JudgeSuggestions(ctx context.Context, projectID, location string, config Ju
 	return result, nil
 }
 
+// cachedVerdict is the subset of a judge.Judgement persisted under a
+// cache.JudgeVerdictKey entry.
+type cachedVerdict struct {
+	Score       float64
+	Reasoning   string
+	Suggestions []string
+}
+
+// scoreSuggestion runs the rubric against a single suggestion, returning the
+// judged suggestion and whether it cleared every criterion's MinScore.
+func scoreSuggestion(ctx context.Context, j judge.Judge, verdictCache cache.Cache, model string, rubric []JudgeCriterion, mode JudgeMode, reference *ReviewResult, s CodeSuggestion) (JudgedSuggestion, bool, error) {
+	suggestionJSON, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return JudgedSuggestion{}, false, fmt.Errorf("marshal suggestion: %w", err)
+	}
+
+	var expectedAnswer string
+	if mode == ModeReference && reference != nil {
+		referenceJSON, err := json.MarshalIndent(reference, "", "  ")
+		if err != nil {
+			return JudgedSuggestion{}, false, fmt.Errorf("marshal reference review: %w", err)
+		}
+		expectedAnswer = string(referenceJSON)
+	}
+
+	subScores := make(map[string]float64, len(rubric))
+	var reasonings []string
+	var improvements []string
+	keep := true
+
+	for _, c := range rubric {
+		verdictKey := cache.JudgeVerdictKey(string(suggestionJSON), c.Description+expectedAnswer, model)
+
+		verdict, err := getCachedVerdict(ctx, verdictCache, verdictKey)
+		if err != nil {
+			return JudgedSuggestion{}, false, fmt.Errorf("read cached verdict: %w", err)
+		}
+
+		if verdict == nil {
+			req := &judge.Request{
+				Mode:         judge.StandaloneMode,
+				ActualAnswer: string(suggestionJSON),
+				Criterion:    c.Description,
+			}
+			if expectedAnswer != "" {
+				req.Mode = judge.ReferenceMode
+				req.ExpectedAnswer = expectedAnswer
+			}
+
+			judgement, err := j.Judge(ctx, req)
+			if err != nil {
+				return JudgedSuggestion{}, false, classifyVertexError(err, fmt.Sprintf("criterion %q", c.Name))
+			}
+
+			verdict = &cachedVerdict{
+				Score:       judgement.Score,
+				Reasoning:   judgement.Reasoning,
+				Suggestions: judgement.Suggestions,
+			}
+			putCachedVerdict(ctx, verdictCache, verdictKey, verdict)
+		}
+
+		subScores[c.Name] = verdict.Score
+		if verdict.Reasoning != "" {
+			reasonings = append(reasonings, fmt.Sprintf("%s: %s", c.Name, verdict.Reasoning))
+		}
+		improvements = append(improvements, verdict.Suggestions...)
+
+		if c.MinScore > 0 && verdict.Score < c.MinScore {
+			keep = false
+		}
+	}
+
+	score := weightedAverage(subScores, rubric)
+
+	js := JudgedSuggestion{
+		Suggestion:  s,
+		Score:       score,
+		Reasoning:   joinReasonings(reasonings),
+		Improvement: improvements,
+		SubScores:   subScores,
+	}
+
+	return js, keep, nil
+}
+
+// getCachedVerdict returns the cached verdict for key, or nil on a cache
+// miss or when no cache is configured.
+func getCachedVerdict(ctx context.Context, c cache.Cache, key string) (*cachedVerdict, error) {
+	if c == nil {
+		return nil, nil
+	}
+	data, ok, err := c.Get(ctx, key)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var verdict cachedVerdict
+	if err := json.Unmarshal(data, &verdict); err != nil {
+		// A corrupt cache entry shouldn't fail the review; treat it as a miss.
+		return nil, nil
+	}
+	return &verdict, nil
+}
+
+// putCachedVerdict stores verdict under key. A no-op when no cache is
+// configured; write failures are logged but not fatal, since the caller
+// already has the judged result.
+func putCachedVerdict(ctx context.Context, c cache.Cache, key string, verdict *cachedVerdict) {
+	if c == nil {
+		return
+	}
+	data, err := json.Marshal(verdict)
+	if err != nil {
+		return
+	}
+	if err := c.Put(ctx, key, data); err != nil {
+		slog.With("component", "judge").Warn("failed to cache verdict", "error", err)
+	}
+}
+
+// weightedAverage computes the weighted average of per-criterion scores,
+// normalizing by the total configured weight.
+func weightedAverage(subScores map[string]float64, rubric []JudgeCriterion) float64 {
+	var total, weightSum float64
+	for _, c := range rubric {
+		weight := c.Weight
+		if weight == 0 {
+			weight = 1.0
+		}
+		total += subScores[c.Name] * weight
+		weightSum += weight
+	}
+	if weightSum == 0 {
+		return 0
+	}
+	return total / weightSum
+}
+
+func joinReasonings(reasonings []string) string {
+	switch len(reasonings) {
+	case 0:
+		return ""
+	case 1:
+		return reasonings[0]
+	default:
+		var sb []byte
+		for i, r := range reasonings {
+			if i > 0 {
+				sb = append(sb, '\n')
+			}
+			sb = append(sb, r...)
+		}
+		return string(sb)
+	}
+}
+
+// resolvePairwise groups suggestions targeting the same file and line range
+// and asks the judge to pick the better one from each group, dropping the
+// loser. Groups with a single suggestion pass through unchanged.
+func resolvePairwise(ctx context.Context, j judge.Judge, log *slog.Logger, suggestions []CodeSuggestion) ([]CodeSuggestion, error) {
+	type groupKey struct {
+		file      string
+		lineStart int
+		lineEnd   int
+	}
+
+	groups := make(map[groupKey][]CodeSuggestion)
+	var order []groupKey
+	for _, s := range suggestions {
+		key := groupKey{file: s.File, lineStart: s.LineStart, lineEnd: s.LineEnd}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], s)
+	}
+
+	var winners []CodeSuggestion
+	for _, key := range order {
+		group := groups[key]
+		if len(group) == 1 {
+			winners = append(winners, group[0])
+			continue
+		}
+
+		winner := group[0]
+		for _, challenger := range group[1:] {
+			better, err := pickBetter(ctx, j, winner, challenger)
+			if err != nil {
+				return nil, err
+			}
+			if better {
+				log.Info("pairwise judge dropped suggestion",
+					"file", challenger.File, "line", challenger.LineEnd)
+				continue
+			}
+			log.Info("pairwise judge dropped suggestion",
+				"file", winner.File, "line", winner.LineEnd)
+			winner = challenger
+		}
+		winners = append(winners, winner)
+	}
+
+	return winners, nil
+}
+
+// pickBetter asks the judge to compare two suggestions and reports whether
+// the incumbent is still the better of the two.
+func pickBetter(ctx context.Context, j judge.Judge, incumbent, challenger CodeSuggestion) (bool, error) {
+	incumbentJSON, err := json.MarshalIndent(incumbent, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("marshal incumbent: %w", err)
+	}
+	challengerJSON, err := json.MarshalIndent(challenger, "", "  ")
+	if err != nil {
+		return false, fmt.Errorf("marshal challenger: %w", err)
+	}
+
+	req := &judge.Request{
+		Mode:         judge.PairwiseMode,
+		ActualAnswer: string(incumbentJSON),
+		Reference:    string(challengerJSON),
+		Criterion:    judgeCriterion,
+	}
+
+	judgement, err := j.Judge(ctx, req)
+	if err != nil {
+		return false, classifyVertexError(err, "pairwise judge")
+	}
+
+	return judgement.Score >= 0.5, nil
+}
+
+// classifyVertexError maps a Vertex AI error into the typed errors callers
+// use to decide whether to retry with backoff or surface the failure.
+// Quota/resource-exhaustion errors become TooManyRequestsError so they can
+// be retried with backoff instead of immediately failing the review.
+func classifyVertexError(err error, op string) error {
+	msg := err.Error()
+	if strings.Contains(msg, "RESOURCE_EXHAUSTED") || strings.Contains(strings.ToLower(msg), "quota") {
+		return rerrors.NewTooManyRequestsError(err, 0, "%s: Vertex AI quota exceeded: %v", op, err)
+	}
+	return rerrors.NewServiceFault(err, "%s: %v", op, err)
+}
+
 // ExtractSuggestions extracts CodeSuggestions from JudgedSuggestions.
 func ExtractSuggestions(judged []JudgedSuggestion) []CodeSuggestion {
 	result := make([]CodeSuggestion, len(judged))
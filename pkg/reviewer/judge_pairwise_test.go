@@ -0,0 +1,163 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reviewer
+
+import (
+	"context"
+	"log/slog"
+	"testing"
+
+	"chainguard.dev/driftless/pkg/judge"
+)
+
+// fakeJudge is a judge.Judge whose verdict is scripted by the test.
+type fakeJudge struct {
+	judgeFn func(ctx context.Context, req *judge.Request) (*judge.Judgement, error)
+}
+
+func (f *fakeJudge) Judge(ctx context.Context, req *judge.Request) (*judge.Judgement, error) {
+	return f.judgeFn(ctx, req)
+}
+
+func TestWeightedAverage(t *testing.T) {
+	tests := []struct {
+		name      string
+		subScores map[string]float64
+		rubric    []JudgeCriterion
+		want      float64
+	}{
+		{
+			name:      "single unweighted criterion",
+			subScores: map[string]float64{"overall": 0.8},
+			rubric:    []JudgeCriterion{{Name: "overall", Weight: 1.0}},
+			want:      0.8,
+		},
+		{
+			name:      "equal weights average",
+			subScores: map[string]float64{"a": 1.0, "b": 0.0},
+			rubric:    []JudgeCriterion{{Name: "a", Weight: 1.0}, {Name: "b", Weight: 1.0}},
+			want:      0.5,
+		},
+		{
+			name:      "unequal weights favor the heavier criterion",
+			subScores: map[string]float64{"a": 1.0, "b": 0.0},
+			rubric:    []JudgeCriterion{{Name: "a", Weight: 3.0}, {Name: "b", Weight: 1.0}},
+			want:      0.75,
+		},
+		{
+			name:      "zero weight defaults to 1.0",
+			subScores: map[string]float64{"a": 0.4},
+			rubric:    []JudgeCriterion{{Name: "a", Weight: 0}},
+			want:      0.4,
+		},
+		{
+			name:      "empty rubric is zero, not a division by zero",
+			subScores: map[string]float64{},
+			rubric:    nil,
+			want:      0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := weightedAverage(tt.subScores, tt.rubric); got != tt.want {
+				t.Errorf("weightedAverage() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPickBetter(t *testing.T) {
+	tests := []struct {
+		name          string
+		score         float64
+		wantIncumbent bool
+	}{
+		{"incumbent wins on a high score", 0.8, true},
+		{"incumbent wins at the threshold", 0.5, true},
+		{"challenger wins on a low score", 0.2, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			j := &fakeJudge{judgeFn: func(_ context.Context, _ *judge.Request) (*judge.Judgement, error) {
+				return &judge.Judgement{Score: tt.score}, nil
+			}}
+
+			incumbent := CodeSuggestion{File: "a.go", Message: "incumbent"}
+			challenger := CodeSuggestion{File: "a.go", Message: "challenger"}
+
+			got, err := pickBetter(context.Background(), j, incumbent, challenger)
+			if err != nil {
+				t.Fatalf("pickBetter() error = %v", err)
+			}
+			if got != tt.wantIncumbent {
+				t.Errorf("pickBetter() = %v, want %v", got, tt.wantIncumbent)
+			}
+		})
+	}
+}
+
+func TestResolvePairwise(t *testing.T) {
+	log := slog.Default()
+
+	t.Run("single-suggestion group passes through unchanged", func(t *testing.T) {
+		j := &fakeJudge{judgeFn: func(_ context.Context, _ *judge.Request) (*judge.Judgement, error) {
+			t.Fatal("judge should not be consulted for a group of one")
+			return nil, nil
+		}}
+
+		suggestions := []CodeSuggestion{{File: "a.go", LineStart: 1, LineEnd: 1, Message: "only one"}}
+		got, err := resolvePairwise(context.Background(), j, log, suggestions)
+		if err != nil {
+			t.Fatalf("resolvePairwise() error = %v", err)
+		}
+		if len(got) != 1 || got[0].Message != "only one" {
+			t.Fatalf("resolvePairwise() = %+v, want the single suggestion unchanged", got)
+		}
+	})
+
+	t.Run("same file and line range keeps only the judge's winner", func(t *testing.T) {
+		j := &fakeJudge{judgeFn: func(_ context.Context, _ *judge.Request) (*judge.Judgement, error) {
+			// Below the 0.5 threshold in pickBetter: the challenger wins.
+			return &judge.Judgement{Score: 0.1}, nil
+		}}
+
+		suggestions := []CodeSuggestion{
+			{File: "a.go", LineStart: 1, LineEnd: 1, Message: "first"},
+			{File: "a.go", LineStart: 1, LineEnd: 1, Message: "second"},
+		}
+		got, err := resolvePairwise(context.Background(), j, log, suggestions)
+		if err != nil {
+			t.Fatalf("resolvePairwise() error = %v", err)
+		}
+		if len(got) != 1 {
+			t.Fatalf("resolvePairwise() returned %d suggestions, want 1", len(got))
+		}
+		if got[0].Message != "second" {
+			t.Errorf("resolvePairwise() winner = %q, want %q", got[0].Message, "second")
+		}
+	})
+
+	t.Run("distinct line ranges are judged independently", func(t *testing.T) {
+		j := &fakeJudge{judgeFn: func(_ context.Context, _ *judge.Request) (*judge.Judgement, error) {
+			t.Fatal("judge should not be consulted when every group has one suggestion")
+			return nil, nil
+		}}
+
+		suggestions := []CodeSuggestion{
+			{File: "a.go", LineStart: 1, LineEnd: 1, Message: "first"},
+			{File: "a.go", LineStart: 2, LineEnd: 2, Message: "second"},
+		}
+		got, err := resolvePairwise(context.Background(), j, log, suggestions)
+		if err != nil {
+			t.Fatalf("resolvePairwise() error = %v", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("resolvePairwise() = %+v, want both suggestions kept", got)
+		}
+	})
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reviewer
+
+import (
+	"errors"
+	"testing"
+
+	rerrors "github.com/example/code-reviewer/pkg/errors"
+)
+
+func TestClassifyVertexError(t *testing.T) {
+	tests := []struct {
+		name             string
+		err              error
+		wantQuotaLimited bool // true -> *rerrors.TooManyRequestsError, false -> *rerrors.ServiceFault
+	}{
+		{"RESOURCE_EXHAUSTED becomes TooManyRequestsError", errors.New("rpc error: code = ResourceExhausted desc = RESOURCE_EXHAUSTED"), true},
+		{"quota message becomes TooManyRequestsError", errors.New("Quota exceeded for quota metric"), true},
+		{"other error becomes ServiceFault", errors.New("rpc error: code = Internal desc = boom"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := classifyVertexError(tt.err, "judge")
+
+			_, isTooManyRequests := got.(*rerrors.TooManyRequestsError)
+			if isTooManyRequests != tt.wantQuotaLimited {
+				t.Errorf("classifyVertexError() = %T, want TooManyRequestsError: %v", got, tt.wantQuotaLimited)
+			}
+
+			_, isServiceFault := got.(*rerrors.ServiceFault)
+			if isServiceFault == tt.wantQuotaLimited {
+				t.Errorf("classifyVertexError() = %T, want ServiceFault: %v", got, !tt.wantQuotaLimited)
+			}
+		})
+	}
+}
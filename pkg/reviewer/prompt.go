@@ -19,6 +19,9 @@ var ReviewPrompt = promptbuilder.MustNewPrompt(`You are an expert code reviewer.
 ## Diff
 {{diff}}
 
+## Prior Findings
+{{prior_findings}}
+
 ## Instructions
 1. Review the code for general quality issues:
    - Bugs and logic errors
@@ -38,9 +41,14 @@ var ReviewPrompt = promptbuilder.MustNewPrompt(`You are an expert code reviewer.
    - Then consider style and best practices
    - Avoid nitpicking or suggesting changes for change's sake
 
-4. Use the read_file tool if you need to see the full content of a file for context.
+4. Prior Findings (if present) are issues a deterministic linter already
+   found on lines in this diff. Don't restate them verbatim: dedupe against
+   your own analysis, drop ones that are false positives or out of scope,
+   and expand on the ones that matter with more specific guidance.
+
+5. Use the read_file tool if you need to see the full content of a file for context.
 
-5. When finished, submit your review using the submit_result tool with:
+6. When finished, submit your review using the submit_result tool with:
    - A summary of your findings
    - A list of suggestions with file, line numbers, severity, message, and suggested fix
    - Whether the PR is approved (no errors found)`)
@@ -62,6 +70,11 @@ type DiffContent struct {
 	Content string `xml:",cdata"`
 }
 
+// PriorFindingsContent wraps the linter findings summary for XML binding.
+type PriorFindingsContent struct {
+	Content string `xml:",cdata"`
+}
+
 // Bind implements promptbuilder.Bindable for ReviewRequest.
 func (r *ReviewRequest) Bind(prompt *promptbuilder.Prompt) (*promptbuilder.Prompt, error) {
 	p, err := prompt.BindXML("pr_info", PRInfo{
@@ -83,5 +96,14 @@ func (r *ReviewRequest) Bind(prompt *promptbuilder.Prompt) (*promptbuilder.Promp
 		return nil, err
 	}
 
+	priorFindings := r.PriorFindings
+	if priorFindings == "" {
+		priorFindings = "(none)"
+	}
+	p, err = p.BindXML("prior_findings", PriorFindingsContent{Content: priorFindings})
+	if err != nil {
+		return nil, err
+	}
+
 	return p, nil
 }
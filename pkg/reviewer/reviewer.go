@@ -9,7 +9,11 @@ import (
 	"context"
 	"fmt"
 	"log/slog"
+	"os"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"chainguard.dev/driftless/pkg/evals"
 	"chainguard.dev/driftless/pkg/executor/claudeexecutor"
@@ -20,7 +24,11 @@ import (
 	"github.com/anthropics/anthropic-sdk-go"
 	"github.com/anthropics/anthropic-sdk-go/shared/constant"
 	"github.com/anthropics/anthropic-sdk-go/vertex"
+	"github.com/example/code-reviewer/pkg/checks"
+	rerrors "github.com/example/code-reviewer/pkg/errors"
 	ghclient "github.com/example/code-reviewer/pkg/github"
+	"github.com/example/code-reviewer/pkg/lint"
+	"github.com/example/code-reviewer/pkg/rate"
 	gh "github.com/google/go-github/v68/github"
 	"google.golang.org/genai"
 )
@@ -31,6 +39,103 @@ type Reviewer struct {
 	claudeExec claudeexecutor.Interface[*ReviewRequest, *ReviewResult]
 	googleExec googleexecutor.Interface[*ReviewRequest, *ReviewResult]
 	provider   string
+
+	// EnabledChecks lists the deterministic pkg/checks checks to run
+	// alongside the LLM pass. "llm-review" is excluded since that's the
+	// AI pass itself, not a Runner check. A nil slice runs every
+	// registered check except "llm-review"; an empty, non-nil slice runs
+	// none.
+	EnabledChecks []string
+
+	linters []lint.Linter
+
+	// MaxInlineComments caps how many suggestions PostReview attaches
+	// directly to the diff as inline comments. Zero or negative uses
+	// DefaultMaxInlineComments. Suggestions beyond the cap are demoted into
+	// the review body's additional-suggestions section rather than dropped.
+	MaxInlineComments int
+
+	// limiter, if set via WithRateLimiter, throttles how many times
+	// Review may run per owner/repo/pr_number within a window. It's
+	// checked before any GitHub or LLM calls are made, so a burst of
+	// webhook events on the same PR can't trigger more than maxEvents
+	// review passes (and their LLM cost) in that time.
+	limiter *rate.SlidingWindowLimiter
+
+	// locker guards Review so only one review pass is in flight per PR at
+	// a time. Lazily defaults to an InMemoryLocker.
+	lockerMu sync.Mutex
+	locker   rate.Locker
+}
+
+// DefaultMaxInlineComments is the inline comment cap used when
+// Reviewer.MaxInlineComments is unset. It keeps a single review from
+// flooding the PR's "Files changed" tab when a large diff surfaces dozens
+// of findings.
+const DefaultMaxInlineComments = 20
+
+// botMarker is embedded in every review body and inline comment this
+// package posts, so PostReview can recognize and dismiss its own prior
+// reviews on a rerun instead of letting stale findings pile up.
+const botMarker = "<!-- code-reviewer:v1 -->"
+
+func (r *Reviewer) maxInlineComments() int {
+	if r.MaxInlineComments > 0 {
+		return r.MaxInlineComments
+	}
+	return DefaultMaxInlineComments
+}
+
+// severityRank orders suggestions so that, when there are more than
+// maxInlineComments candidates, the lowest-severity ones are the ones
+// demoted to the summary section instead of the most important.
+func severityRank(severity string) int {
+	switch strings.ToLower(severity) {
+	case SeverityError:
+		return 0
+	case SeverityWarning:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// WithLinters configures the static analyzers Review runs against the PR
+// head before the AI pass, feeding their findings into the prompt's
+// {{prior_findings}} block instead of leaving the model to re-derive the
+// same style issues from scratch.
+func (r *Reviewer) WithLinters(linters ...lint.Linter) *Reviewer {
+	r.linters = linters
+	return r
+}
+
+// WithRateLimiter configures a sliding-window limit on how many times
+// Review may run per owner/repo/pr_number within window, so a burst of
+// webhook events (push, synchronize, review_requested) on the same PR
+// can't trigger more than maxEvents review passes — and their LLM cost —
+// in that time.
+func (r *Reviewer) WithRateLimiter(window time.Duration, maxEvents int) *Reviewer {
+	r.limiter = rate.NewSlidingWindowLimiter(window, maxEvents)
+	return r
+}
+
+// SetLocker configures the distributed mutex Review uses to ensure only
+// one review pass is in flight per PR at a time. Defaults to an
+// InMemoryLocker; pass a rate.RedisLocker so multiple reviewer instances
+// share the guarantee.
+func (r *Reviewer) SetLocker(locker rate.Locker) {
+	r.lockerMu.Lock()
+	defer r.lockerMu.Unlock()
+	r.locker = locker
+}
+
+func (r *Reviewer) getLocker() rate.Locker {
+	r.lockerMu.Lock()
+	defer r.lockerMu.Unlock()
+	if r.locker == nil {
+		r.locker = rate.NewInMemoryLocker()
+	}
+	return r.locker
 }
 
 // NewWithClaude creates a new Reviewer using Claude via Vertex AI.
@@ -104,71 +209,188 @@ func (r *Reviewer) Review(ctx context.Context, owner, repo string, prNumber int)
 		return nil, fmt.Errorf("GitHub client not set")
 	}
 
+	prKey := fmt.Sprintf("%s/%s/%d", owner, repo, prNumber)
+
+	if r.limiter != nil && !r.limiter.Allow(prKey, time.Now()) {
+		return nil, rerrors.NewTooManyRequestsError(nil, r.limiter.Window(),
+			"too many review events for %s within %s, dropping this one", prKey, r.limiter.Window())
+	}
+
+	locker := r.getLocker()
+	acquired, err := locker.TryLock(ctx, prKey)
+	if err != nil {
+		return nil, fmt.Errorf("acquire review lock for %s: %w", prKey, err)
+	}
+	if !acquired {
+		return nil, rerrors.NewTooManyRequestsError(nil, 0, "a review is already in flight for %s, dropping this one", prKey)
+	}
+	defer locker.Unlock(ctx, prKey)
+
 	log.Info("fetching PR metadata")
 	pr, err := r.github.GetPR(ctx, owner, repo, prNumber)
 	if err != nil {
 		return nil, fmt.Errorf("fetch PR %s/%s#%d metadata: %w", owner, repo, prNumber, err)
 	}
 
+	sha := pr.GetHead().GetSHA()
+
 	log.Info("fetching PR diff")
-	diff, err := r.github.GetPRDiff(ctx, owner, repo, prNumber)
+	diff, err := r.github.GetPRDiff(ctx, owner, repo, prNumber, sha)
 	if err != nil {
 		return nil, fmt.Errorf("fetch PR %s/%s#%d diff: %w", owner, repo, prNumber, err)
 	}
 
 	log.Info("fetching changed files")
-	files, err := r.github.GetPRFiles(ctx, owner, repo, prNumber)
+	files, err := r.github.GetPRFiles(ctx, owner, repo, prNumber, sha)
 	if err != nil {
 		return nil, fmt.Errorf("fetch PR %s/%s#%d files: %w", owner, repo, prNumber, err)
 	}
 
-	log.Info("starting AI review", "files_count", len(files), "diff_size", len(diff))
+	lintFindings := r.runLinters(ctx, log, owner, repo, sha, files, diff)
 
-	// Build the request
-	request := &ReviewRequest{
-		Repo:        fmt.Sprintf("%s/%s", owner, repo),
-		Title:       pr.GetTitle(),
-		Description: pr.GetBody(),
-		Files:       formatFiles(files),
-		Diff:        diff,
-	}
+	var result *ReviewResult
+	if r.llmReviewEnabled() {
+		log.Info("starting AI review", "files_count", len(files), "diff_size", len(diff))
+
+		request := &ReviewRequest{
+			Repo:          fmt.Sprintf("%s/%s", owner, repo),
+			Title:         pr.GetTitle(),
+			Description:   pr.GetBody(),
+			Files:         formatFiles(files),
+			Diff:          diff,
+			PriorFindings: formatLintFindings(lintFindings),
+		}
 
-	sha := pr.GetHead().GetSHA()
+		switch r.provider {
+		case "claude":
+			result, err = r.reviewWithClaude(ctx, request, owner, repo, sha)
+		case "gemini":
+			result, err = r.reviewWithGemini(ctx, request, owner, repo, sha)
+		default:
+			return nil, fmt.Errorf("unknown provider: %s", r.provider)
+		}
 
-	// Execute with the appropriate provider
-	var result *ReviewResult
-	switch r.provider {
-	case "claude":
-		result, err = r.reviewWithClaude(ctx, request, owner, repo, sha)
-	case "gemini":
-		result, err = r.reviewWithGemini(ctx, request, owner, repo, sha)
-	default:
-		return nil, fmt.Errorf("unknown provider: %s", r.provider)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		log.Info("skipping AI review pass: llm-review not in -checks")
+		result = &ReviewResult{
+			Summary:  "AI review pass skipped (llm-review not enabled via -checks).",
+			Approved: true,
+		}
 	}
 
-	if err != nil {
-		return nil, err
+	checkFindings := r.runChecks(ctx, log, owner, repo, pr, files, diff)
+	if len(checkFindings) > 0 {
+		result.Suggestions = append(result.Suggestions, checkFindings...)
+		if len(result.Suggestions) > 0 && hasError(checkFindings) {
+			result.Approved = false
+		}
 	}
 
 	log.Info("review completed", "suggestions", len(result.Suggestions), "approved", result.Approved)
 
 	return &ReviewOutput{
-		Result:    result,
-		CommitSHA: sha,
-		Diff:      diff,
+		Result:       result,
+		CommitSHA:    sha,
+		Diff:         diff,
+		LintFindings: lintFindings,
 	}, nil
 }
 
+// runLinters checks out the PR head and runs every configured linter that
+// applies to the changed files, filtering findings down to lines actually
+// present in the diff. Failures are logged and otherwise non-fatal: a
+// broken linter shouldn't block the AI review pass.
+func (r *Reviewer) runLinters(ctx context.Context, log *slog.Logger, owner, repo, sha string, files []*gh.CommitFile, diff string) []lint.LintFinding {
+	if len(r.linters) == 0 {
+		return nil
+	}
+
+	paths := make([]string, len(files))
+	for i, f := range files {
+		paths[i] = f.GetFilename()
+	}
+
+	var applicable []lint.Linter
+	for _, l := range r.linters {
+		if l.Applies(paths) {
+			applicable = append(applicable, l)
+		}
+	}
+	if len(applicable) == 0 {
+		return nil
+	}
+
+	token, err := r.github.CloneCredential(ctx)
+	if err != nil {
+		log.Warn("lint pre-pass: failed to mint clone credential, skipping", "error", err)
+		return nil
+	}
+
+	workdir, err := checkoutPRHead(ctx, owner, repo, sha, token)
+	if err != nil {
+		log.Warn("lint pre-pass: failed to check out PR head, skipping", "error", err)
+		return nil
+	}
+	defer os.RemoveAll(workdir)
+
+	diffInfo := newDiffLines(diff)
+
+	var findings []lint.LintFinding
+	for _, l := range applicable {
+		found, err := l.Run(ctx, workdir, paths)
+		if err != nil {
+			log.Warn("linter failed", "linter", l.Name(), "error", err)
+			continue
+		}
+		for _, f := range found {
+			if diffInfo.contains(f.File, f.Line) {
+				findings = append(findings, f)
+			}
+		}
+	}
+
+	log.Info("lint pre-pass complete", "linters", len(applicable), "findings", len(findings))
+	return findings
+}
+
+// formatLintFindings renders lint findings as a plain-text block for the
+// {{prior_findings}} prompt placeholder.
+func formatLintFindings(findings []lint.LintFinding) string {
+	if len(findings) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	for _, f := range findings {
+		sb.WriteString(fmt.Sprintf("- %s:%d [%s/%s] %s\n", f.File, f.Line, f.Rule, f.Severity, f.Message))
+	}
+	return sb.String()
+}
+
 func (r *Reviewer) reviewWithClaude(ctx context.Context, request *ReviewRequest, owner, repo, sha string) (*ReviewResult, error) {
+	blame := &blameRunner{github: r.github, owner: owner, repo: repo, sha: sha}
+	defer blame.cleanup()
+
 	tools := map[string]claudeexecutor.ToolMetadata[*ReviewResult]{
-		"read_file": r.claudeReadFileTool(owner, repo, sha),
+		"read_file":   r.claudeReadFileTool(owner, repo, sha),
+		"git_blame":   r.claudeGitBlameTool(blame),
+		"list_dir":    r.claudeListDirTool(owner, repo, sha),
+		"search_code": r.claudeSearchCodeTool(owner, repo),
 	}
 	return r.claudeExec.Execute(ctx, request, tools)
 }
 
 func (r *Reviewer) reviewWithGemini(ctx context.Context, request *ReviewRequest, owner, repo, sha string) (*ReviewResult, error) {
+	blame := &blameRunner{github: r.github, owner: owner, repo: repo, sha: sha}
+	defer blame.cleanup()
+
 	tools := map[string]googleexecutor.ToolMetadata[*ReviewResult]{
-		"read_file": r.geminiReadFileTool(owner, repo, sha),
+		"read_file":   r.geminiReadFileTool(owner, repo, sha),
+		"git_blame":   r.geminiGitBlameTool(blame),
+		"list_dir":    r.geminiListDirTool(owner, repo, sha),
+		"search_code": r.geminiSearchCodeTool(owner, repo),
 	}
 	return r.googleExec.Execute(ctx, request, tools)
 }
@@ -267,18 +489,32 @@ func (r *Reviewer) PostReview(ctx context.Context, owner, repo string, prNumber
 		return fmt.Errorf("GitHub client not set")
 	}
 
+	r.dismissPriorReviews(ctx, log, owner, repo, prNumber)
+
 	// Parse cached diff to get valid line ranges per file
 	diffInfo := newDiffLines(output.Diff)
 
 	result := output.Result
 
+	// Rank by severity first so that, if there are more diff-eligible
+	// suggestions than the inline comment cap, it's the lowest-severity
+	// ones that get demoted to the summary rather than the most important.
+	ranked := make([]CodeSuggestion, len(result.Suggestions))
+	copy(ranked, result.Suggestions)
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return severityRank(ranked[i].Severity) < severityRank(ranked[j].Severity)
+	})
+
+	maxInline := r.maxInlineComments()
+
 	// Build inline comments for suggestions with valid line numbers
 	var comments []*gh.DraftReviewComment
 	var unresolvedSuggestions []CodeSuggestion
 
-	for _, s := range result.Suggestions {
-		// Check if the line is in the diff
-		if diffInfo.contains(s.File, s.LineEnd) {
+	for _, s := range ranked {
+		// Check if the line is in the diff, and that we haven't already hit
+		// the inline comment cap.
+		if diffInfo.contains(s.File, s.LineEnd) && len(comments) < maxInline {
 			comment := buildReviewComment(s, diffInfo)
 			comments = append(comments, comment)
 		} else {
@@ -290,9 +526,11 @@ func (r *Reviewer) PostReview(ctx context.Context, owner, repo string, prNumber
 	var body strings.Builder
 	body.WriteString(result.Summary)
 
-	// Add unresolved suggestions to the body (lines not in diff)
+	// Add suggestions that didn't become inline comments, either because
+	// their lines aren't in the diff or because the inline comment cap was
+	// reached.
 	if len(unresolvedSuggestions) > 0 {
-		body.WriteString("\n\n---\n\n## Additional Suggestions (outside diff context)\n\n")
+		body.WriteString("\n\n---\n\n## Additional Suggestions\n\n")
 		for i, s := range unresolvedSuggestions {
 			body.WriteString(fmt.Sprintf("### %d. `%s` (lines %d-%d) - %s\n\n",
 				i+1, s.File, s.LineStart, s.LineEnd, s.NormalizedSeverity()))
@@ -304,6 +542,8 @@ func (r *Reviewer) PostReview(ctx context.Context, owner, repo string, prNumber
 		}
 	}
 
+	body.WriteString("\n\n" + botMarker)
+
 	// Determine review event
 	event := "COMMENT"
 	if result.Approved {
@@ -332,6 +572,144 @@ func (r *Reviewer) PostReview(ctx context.Context, owner, repo string, prNumber
 	return nil
 }
 
+// supersededReviewBody replaces the body of a prior bot review that
+// GitHub won't let us dismiss (state COMMENTED), so a rerun doesn't leave
+// the full text of a stale review sitting alongside the new one.
+const supersededReviewBody = "_Superseded by a newer review on this PR._\n\n" + botMarker
+
+// dismissPriorReviews finds reviews and inline comments this package
+// previously posted on the PR (identified by botMarker in the body) and
+// clears them out so a rerun doesn't pile up duplicate content. GitHub
+// only allows dismissing a review in the CHANGES_REQUESTED or APPROVED
+// state; a COMMENTED review (the common case here, since PostReview only
+// submits COMMENT or APPROVE) can't be dismissed at all, so those have
+// their body edited in place instead. A review's body and its inline
+// comments are separate entities — dismissing or editing the former
+// doesn't touch the latter — so prior inline comments are deleted
+// outright rather than left to accumulate in the Files changed tab.
+func (r *Reviewer) dismissPriorReviews(ctx context.Context, log *slog.Logger, owner, repo string, prNumber int) {
+	reviews, err := r.github.ListReviews(ctx, owner, repo, prNumber)
+	if err != nil {
+		log.Warn("failed to list prior reviews for cleanup", "error", err)
+		return
+	}
+
+	for _, rv := range reviews {
+		if !strings.Contains(rv.GetBody(), botMarker) {
+			continue
+		}
+
+		switch rv.GetState() {
+		case "CHANGES_REQUESTED", "APPROVED":
+			if err := r.github.DismissReview(ctx, owner, repo, prNumber, rv.GetID(), "superseded by a new review"); err != nil {
+				log.Debug("could not dismiss prior bot review", "review_id", rv.GetID(), "error", err)
+			}
+		default:
+			if err := r.github.UpdateReview(ctx, owner, repo, prNumber, rv.GetID(), supersededReviewBody); err != nil {
+				log.Debug("could not edit prior bot review in place", "review_id", rv.GetID(), "error", err)
+			}
+		}
+	}
+
+	comments, err := r.github.ListReviewComments(ctx, owner, repo, prNumber)
+	if err != nil {
+		log.Warn("failed to list prior inline comments for cleanup", "error", err)
+		return
+	}
+
+	for _, c := range comments {
+		if !strings.Contains(c.GetBody(), botMarker) {
+			continue
+		}
+		if err := r.github.DeleteReviewComment(ctx, owner, repo, c.GetID()); err != nil {
+			log.Debug("could not delete prior bot inline comment", "comment_id", c.GetID(), "error", err)
+		}
+	}
+}
+
+// llmReviewEnabled reports whether the AI review pass should run. It
+// mirrors how runChecks treats EnabledChecks for the deterministic
+// checks: a nil slice (the default, "run everything") includes the LLM
+// pass, while a non-nil slice runs it only if "llm-review" is explicitly
+// listed, so "-checks=secret-scan" can opt out of the AI pass entirely.
+func (r *Reviewer) llmReviewEnabled() bool {
+	if r.EnabledChecks == nil {
+		return true
+	}
+	for _, name := range r.EnabledChecks {
+		if name == "llm-review" {
+			return true
+		}
+	}
+	return false
+}
+
+// runChecks executes the deterministic pkg/checks checks configured on
+// r.EnabledChecks and converts their findings into CodeSuggestions.
+func (r *Reviewer) runChecks(ctx context.Context, log *slog.Logger, owner, repo string, pr *gh.PullRequest, files []*gh.CommitFile, diff string) []CodeSuggestion {
+	names := r.EnabledChecks
+	if names == nil {
+		for name := range checks.CheckNameToFnMap {
+			if name == "llm-review" {
+				continue
+			}
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	runner := &checks.Runner{Names: names}
+	req := &checks.CheckRequest{
+		GitHub:     r.github,
+		Owner:      owner,
+		Repo:       repo,
+		PR:         pr,
+		Files:      files,
+		Diff:       diff,
+		AddedLines: checks.ParseAddedLines(diff),
+		Logger:     slogDetailLogger{log},
+	}
+
+	var suggestions []CodeSuggestion
+	for _, result := range runner.Run(ctx, req) {
+		if result.Error != nil {
+			log.Warn("check failed", "check", result.Name, "error", result.Error)
+			continue
+		}
+		for _, f := range result.Findings {
+			suggestions = append(suggestions, CodeSuggestion{
+				File:       f.File,
+				LineStart:  f.LineStart,
+				LineEnd:    f.LineEnd,
+				Severity:   f.Severity,
+				Message:    f.Message,
+				Suggestion: f.Suggestion,
+			})
+		}
+	}
+	return suggestions
+}
+
+// hasError reports whether any suggestion has error severity.
+func hasError(suggestions []CodeSuggestion) bool {
+	for _, s := range suggestions {
+		if s.IsError() {
+			return true
+		}
+	}
+	return false
+}
+
+// slogDetailLogger adapts *slog.Logger to checks.DetailLogger.
+type slogDetailLogger struct {
+	log *slog.Logger
+}
+
+func (l slogDetailLogger) Info(msg string, args ...any) { l.log.Info(msg, args...) }
+func (l slogDetailLogger) Warn(msg string, args ...any) { l.log.Warn(msg, args...) }
+
 // extractCodeFromSuggestion extracts raw code from a suggestion that may contain markdown.
 // If the suggestion contains markdown code fences (```), it extracts only the code.
 // Otherwise, returns the suggestion as-is.
@@ -387,6 +765,7 @@ func buildReviewComment(s CodeSuggestion, diffInfo *diffLines) *gh.DraftReviewCo
 		code := extractCodeFromSuggestion(s.Suggestion)
 		body += fmt.Sprintf("\n\n```suggestion\n%s\n```", code)
 	}
+	body += "\n\n" + botMarker
 
 	comment := &gh.DraftReviewComment{
 		Path: ghclient.Ptr(s.File),
@@ -421,10 +800,10 @@ func newDiffLines(diff string) *diffLines {
 	lines := strings.Split(diff, "\n")
 
 	var (
-		currentFile  string
-		currentLine  int
-		rangeStart   int
-		inRange      bool
+		currentFile string
+		currentLine int
+		rangeStart  int
+		inRange     bool
 	)
 
 	flushRange := func() {
@@ -533,4 +912,3 @@ func formatFiles(files []*gh.CommitFile) string {
 	}
 	return sb.String()
 }
-
@@ -0,0 +1,95 @@
+/*
+Copyright 2025 Chainguard, Inc.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package reviewer
+
+import (
+	"github.com/anthropics/anthropic-sdk-go"
+	"github.com/anthropics/anthropic-sdk-go/shared/constant"
+	"google.golang.org/genai"
+)
+
+// toolParam describes one parameter of a toolDescriptor in a provider-
+// agnostic form.
+type toolParam struct {
+	Name        string
+	Type        string // "string", "integer", "number", or "boolean"
+	Description string
+	Required    bool
+}
+
+// toolDescriptor is a tool's name, description, and parameter schema,
+// defined once and rendered into either provider's tool format by
+// claudeDefinition/geminiDefinition. This keeps the read_file-style tools
+// from needing the same schema written out twice per tool.
+type toolDescriptor struct {
+	Name        string
+	Description string
+	Params      []toolParam
+}
+
+func (d toolDescriptor) requiredParams() []string {
+	var required []string
+	for _, p := range d.Params {
+		if p.Required {
+			required = append(required, p.Name)
+		}
+	}
+	return required
+}
+
+// claudeDefinition renders d into Claude's anthropic.ToolParam format.
+func (d toolDescriptor) claudeDefinition() anthropic.ToolParam {
+	properties := make(map[string]any, len(d.Params))
+	for _, p := range d.Params {
+		properties[p.Name] = map[string]any{
+			"type":        p.Type,
+			"description": p.Description,
+		}
+	}
+	return anthropic.ToolParam{
+		Name:        d.Name,
+		Description: anthropic.String(d.Description),
+		InputSchema: anthropic.ToolInputSchemaParam{
+			Type:       constant.Object("object"),
+			Properties: properties,
+			Required:   d.requiredParams(),
+		},
+	}
+}
+
+// geminiDefinition renders d into Gemini's genai.FunctionDeclaration format.
+func (d toolDescriptor) geminiDefinition() *genai.FunctionDeclaration {
+	properties := make(map[string]*genai.Schema, len(d.Params))
+	for _, p := range d.Params {
+		properties[p.Name] = &genai.Schema{
+			Type:        geminiParamType(p.Type),
+			Description: p.Description,
+		}
+	}
+	return &genai.FunctionDeclaration{
+		Name:        d.Name,
+		Description: d.Description,
+		Parameters: &genai.Schema{
+			Type:       genai.TypeObject,
+			Properties: properties,
+			Required:   d.requiredParams(),
+		},
+	}
+}
+
+// geminiParamType maps a toolParam.Type string to Gemini's Type enum.
+func geminiParamType(t string) genai.Type {
+	switch t {
+	case "integer":
+		return genai.TypeInteger
+	case "number":
+		return genai.TypeNumber
+	case "boolean":
+		return genai.TypeBoolean
+	default:
+		return genai.TypeString
+	}
+}
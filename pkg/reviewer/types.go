@@ -5,7 +5,11 @@ SPDX-License-Identifier: Apache-2.0
 
 package reviewer
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/example/code-reviewer/pkg/lint"
+)
 
 // Severity levels for code review suggestions.
 const (
@@ -63,6 +67,11 @@ type ReviewRequest struct {
 	Description string `json:"description"`
 	Files       string `json:"files"`
 	Diff        string `json:"diff"`
+
+	// PriorFindings is a formatted summary of deterministic linter output
+	// (see pkg/lint), scoped to lines changed in the diff. Empty when no
+	// linters are configured or none of them applied to this PR.
+	PriorFindings string `json:"prior_findings"`
 }
 
 // ReviewOutput contains the review result and metadata needed for posting.
@@ -70,4 +79,9 @@ type ReviewOutput struct {
 	Result    *ReviewResult
 	CommitSHA string
 	Diff      string // Cached diff for posting without re-fetching
+
+	// LintFindings holds the raw linter output that fed into
+	// ReviewRequest.PriorFindings, so callers (e.g. -dry-run) can inspect
+	// deterministic and AI findings separately.
+	LintFindings []lint.LintFinding
 }